@@ -86,7 +86,7 @@ func main() {
 
 	// Initialize database connection
 	log.Printf("Connecting to database...")
-	db, err := database.New(cfg.DatabaseURL)
+	db, err := database.New(cfg.DatabaseURL, database.PoolConfigFromConfig(cfg))
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -110,6 +110,7 @@ func main() {
 	fmt.Printf("   • Max Concurrent: %d operations\n", pipelineConfig.MaxConcurrent)
 	fmt.Printf("   • Process New Only: %v\n", pipelineConfig.ProcessNewOnly)
 	fmt.Printf("   • Rescore After: %d days\n", pipelineConfig.RescoreOlderThanDays)
+	fmt.Printf("   • Prioritize Rescraped: %v\n", pipelineConfig.PrioritizeRescraped)
 
 	// Start the pipeline
 	if err := pipeline.Start(pipelineConfig); err != nil {
@@ -381,6 +382,10 @@ func parsePipelineConfig() services.PipelineConfig {
 		}
 	}
 
+	if val := os.Getenv("PIPELINE_PRIORITIZE_RESCRAPED"); val != "" {
+		config.PrioritizeRescraped = val == "true"
+	}
+
 	return config
 }
 
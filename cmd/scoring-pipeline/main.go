@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -16,6 +17,11 @@ import (
 )
 
 func main() {
+	once := flag.Bool("once", false, "Run a single scoring cycle and exit")
+	outputPath := flag.String("output", "", "With --once, write the cycle results to this file")
+	outputFormat := flag.String("format", "", "Output file format: json or csv (default: from file extension, else json)")
+	flag.Parse()
+
 	fmt.Println("🎯 OTC Markets Automated Scoring Pipeline")
 	fmt.Println("==========================================")
 
@@ -28,7 +34,7 @@ func main() {
 	cfg := config.New()
 
 	// Initialize database
-	db, err := database.New(cfg.DatabaseURL)
+	db, err := database.New(cfg.DatabaseURL, database.PoolConfigFromConfig(cfg))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -46,9 +52,10 @@ func main() {
 	fmt.Printf("   • Max Concurrent: %d operations\n", pipelineConfig.MaxConcurrent)
 	fmt.Printf("   • Process New Only: %v\n", pipelineConfig.ProcessNewOnly)
 	fmt.Printf("   • Rescore After: %d days\n", pipelineConfig.RescoreOlderThanDays)
+	fmt.Printf("   • Prioritize Rescraped: %v\n", pipelineConfig.PrioritizeRescraped)
 
 	// Check if this is a one-time run
-	if len(os.Args) > 1 && os.Args[1] == "--once" {
+	if *once {
 		fmt.Println("\n🔄 Running one-time scoring cycle...")
 		stats, err := pipeline.RunOnce(pipelineConfig)
 		if err != nil {
@@ -62,6 +69,18 @@ func main() {
 		fmt.Printf("   • Companies Succeeded: %d\n", stats.CompaniesSucceeded)
 		fmt.Printf("   • Companies Failed: %d\n", stats.CompaniesFailed)
 		fmt.Printf("   • Models Applied: %d\n", stats.ModelsApplied)
+
+		if *outputPath != "" {
+			report, err := pipeline.BuildCycleReport(stats)
+			if err != nil {
+				log.Fatalf("❌ Failed to build cycle report: %v", err)
+			}
+			format := resolveOutputFormat(*outputPath, *outputFormat)
+			if err := writeCycleReport(report, *outputPath, format); err != nil {
+				log.Fatalf("❌ Failed to write cycle report: %v", err)
+			}
+			fmt.Printf("   • Results written to %s (%s)\n", *outputPath, format)
+		}
 		return
 	}
 
@@ -122,5 +141,9 @@ func parsePipelineConfig() services.PipelineConfig {
 		}
 	}
 
+	if val := os.Getenv("PIPELINE_PRIORITIZE_RESCRAPED"); val != "" {
+		config.PrioritizeRescraped = val == "true"
+	}
+
 	return config
 }
\ No newline at end of file
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
+)
+
+// resolveOutputFormat picks the explicit format, else infers it from the file extension
+func resolveOutputFormat(path, format string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return "csv"
+	}
+	return "json"
+}
+
+// writeCycleReport writes the cycle report to path, creating parent directories as needed
+func writeCycleReport(report *services.CycleReport, path, format string) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(report, "", "  ")
+	case "csv":
+		data, err = cycleReportCSV(report)
+	default:
+		return fmt.Errorf("unsupported output format %q (use json or csv)", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	// Write to a temp file and rename so cron consumers never see a partial artifact
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// cycleReportCSV renders one row per company and model; failed companies get a single row
+func cycleReportCSV(report *services.CycleReport) ([]byte, error) {
+	var output strings.Builder
+	writer := csv.NewWriter(&output)
+
+	headers := []string{
+		"company_id", "ticker", "company_name", "succeeded", "error",
+		"model_name", "score", "score_percent", "qualified", "requirements_met",
+	}
+	if err := writer.Write(headers); err != nil {
+		return nil, err
+	}
+
+	for _, company := range report.Companies {
+		base := []string{
+			company.ID,
+			company.Ticker,
+			company.CompanyName,
+			strconv.FormatBool(company.Succeeded),
+			company.Error,
+		}
+
+		if len(company.Scores) == 0 {
+			if err := writer.Write(append(base, "", "", "", "", "")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, score := range company.Scores {
+			row := append(append([]string{}, base...),
+				score.ModelName,
+				strconv.Itoa(score.Score),
+				strconv.FormatFloat(score.ScorePercent, 'f', 1, 64),
+				strconv.FormatBool(score.Qualified),
+				strconv.FormatBool(score.RequirementsMet),
+			)
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return []byte(output.String()), nil
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -22,7 +28,7 @@ func main() {
 	cfg := config.New()
 
 	// Initialize database
-	db, err := database.New(cfg.DatabaseURL)
+	db, err := database.New(cfg.DatabaseURL, database.PoolConfigFromConfig(cfg))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -41,11 +47,15 @@ func main() {
 	// Initialize router
 	r := gin.New()
 	
+	// Record per-route request metrics before anything can abort the request
+	metrics := middleware.NewMetrics()
+	r.Use(metrics.Middleware())
+	
 	// Add security middleware
 	r.Use(middleware.LoggingMiddleware())
 	r.Use(middleware.SecurityHeadersMiddleware())
 	r.Use(middleware.CORSMiddleware(cfg))
-	r.Use(middleware.InputValidationMiddleware())
+	r.Use(middleware.InputValidationMiddlewareWithPolicy(middleware.ContentTypePolicyFromConfig(cfg)))
 	
 	// Add rate limiting in production
 	if cfg.EnableRateLimit {
@@ -56,9 +66,13 @@ func main() {
 	r.Use(gin.Recovery())
 	
 	// Setup API routes
-	if err := api.SetupRoutes(r, db, cfg); err != nil {
+	backgroundJobs, err := api.SetupRoutes(r, db, cfg, metrics)
+	if err != nil {
 		log.Fatal("Failed to setup API routes:", err)
 	}
+	
+	// Prometheus metrics endpoint
+	r.GET("/metrics", metrics.Handler())
 
 	// Start server
 	port := os.Getenv("PORT")
@@ -66,8 +80,48 @@ func main() {
 		port = "8080"
 	}
 	
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
+	// Bound how long a client can hold a connection so slow or hung clients
+	// can't tie up the server
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      r,
+		ReadTimeout:  time.Duration(cfg.HTTPReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(cfg.HTTPWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(cfg.HTTPIdleTimeoutSeconds) * time.Second,
+	}
+
+	// Start retention cleanup and the requirements sweeper alongside the server
+	if err := backgroundJobs.Start(); err != nil {
+		log.Fatal("Failed to start background jobs:", err)
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	// Wait for a shutdown signal or a server failure
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-sigChan:
+		log.Println("Shutdown signal received, stopping server...")
+	case err := <-serverErr:
+		backgroundJobs.Stop()
 		log.Fatal("Failed to start server:", err)
 	}
+
+	// Let in-flight requests finish before stopping the background jobs they may rely on
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+	}
+
+	backgroundJobs.Stop()
+	log.Println("Server stopped")
 }
\ No newline at end of file
@@ -42,7 +42,7 @@ func main() {
 	var db *database.DB
 	var err error
 	if !*healthOnly {
-		db, err = database.New(cfg.DatabaseURL)
+		db, err = database.New(cfg.DatabaseURL, database.PoolConfigFromConfig(cfg))
 		if err != nil {
 			log.Fatalf("Failed to connect to database: %v", err)
 		}
@@ -75,7 +75,7 @@ func main() {
 	fmt.Printf("Scraping ticker: %s\n", *ticker)
 	
 	startTime := time.Now()
-	company, err := service.ScrapeAndStore(ctx, *ticker)
+	company, err := service.ScrapeAndStore(ctx, *ticker, scraper.OxyLabsUsageCLI)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -21,7 +21,7 @@ func main() {
 		// Basic company info
 		"ticker":         "ECGP",
 		"company_name":   "Envit Capital Group, Inc.",
-		"market_tier":    "Pink Limited",
+		"market_tier":    "PinkLimited",
 		"quote_status":   "Caveat Emptor",
 		"trading_volume": int64(0), // No trading volume
 
@@ -79,7 +79,7 @@ func main() {
 	highQualityData := map[string]interface{}{
 		"ticker":                  "HQTK",
 		"company_name":           "High Quality Test Corp",
-		"market_tier":            "Expert Market",
+		"market_tier":            "ExpertMarket",
 		"quote_status":           "Ineligible for solicited quotes",
 		"trading_volume":         int64(1000),
 		"delinquent_10k":         false,
@@ -130,7 +130,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Generate JWT token
-	token, expiresAt, err := auth.GenerateJWT(user.ID, user.Role, h.cfg.JWTSecret)
+	token, expiresAt, err := auth.GenerateJWTWithKeys(user.ID, user.Role, auth.KeySetFromConfig(h.cfg))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
@@ -220,7 +220,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Generate JWT token
-	token, expiresAt, err := auth.GenerateJWT(user.ID, user.Role, h.cfg.JWTSecret)
+	token, expiresAt, err := auth.GenerateJWTWithKeys(user.ID, user.Role, auth.KeySetFromConfig(h.cfg))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scraper"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
 func TestHealthEndpoints(t *testing.T) {
@@ -20,7 +21,7 @@ func TestHealthEndpoints(t *testing.T) {
 	
 	// Create mock service with health monitoring
 	mockService := NewMockScraperService()
-	handler := NewUploadHandler(mockService)
+	handler := NewUploadHandler(mockService, config.New())
 	
 	// Add auth middleware mock
 	router.Use(func(c *gin.Context) {
@@ -16,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
 // MockScraperService implements the scraper service interface for testing
@@ -31,7 +32,7 @@ func NewMockScraperService() *MockScraperService {
 	}
 }
 
-func (m *MockScraperService) ScrapeTickersBatch(ctx context.Context, tickers []string, userID uuid.UUID, useOptimized bool) (*models.ScrapeJob, error) {
+func (m *MockScraperService) ScrapeTickersBatch(ctx context.Context, tickers []string, userID uuid.UUID, useOptimized bool, priority models.ScrapeJobPriority) (*models.ScrapeJob, error) {
 	job := &models.ScrapeJob{
 		ID:               uuid.New(),
 		Status:           string(models.ScrapeJobPending),
@@ -40,19 +41,29 @@ func (m *MockScraperService) ScrapeTickersBatch(ctx context.Context, tickers []s
 		FailedTickers:    0,
 		StartedBy:        userID,
 		StartedAt:        time.Now(),
+		Priority:         string(priority),
 	}
 	m.jobs[job.ID] = job
 	return job, nil
 }
 
-func (m *MockScraperService) GetUserJobs(ctx context.Context, userID uuid.UUID) ([]*models.ScrapeJob, error) {
+func (m *MockScraperService) GetUserJobs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.ScrapeJob, int, error) {
 	var jobs []*models.ScrapeJob
 	for _, job := range m.jobs {
 		if job.StartedBy == userID {
 			jobs = append(jobs, job)
 		}
 	}
-	return jobs, nil
+	total := len(jobs)
+	if offset > total {
+		offset = total
+	}
+	if end := offset + limit; end < total {
+		jobs = jobs[offset:end]
+	} else {
+		jobs = jobs[offset:]
+	}
+	return jobs, total, nil
 }
 
 func (m *MockScraperService) GetJob(ctx context.Context, jobID uuid.UUID) (*models.ScrapeJob, error) {
@@ -85,7 +96,7 @@ func TestUploadHandlerCSVUpload(t *testing.T) {
 	router := gin.New()
 	
 	mockService := NewMockScraperService()
-	handler := NewUploadHandler(mockService)
+	handler := NewUploadHandler(mockService, config.New())
 	
 	// Add a mock middleware to set user ID
 	router.Use(func(c *gin.Context) {
@@ -145,7 +156,7 @@ func TestUploadHandlerInvalidCSV(t *testing.T) {
 	router := gin.New()
 	
 	mockService := NewMockScraperService()
-	handler := NewUploadHandler(mockService)
+	handler := NewUploadHandler(mockService, config.New())
 	
 	router.Use(func(c *gin.Context) {
 		c.Set("user_id", uuid.New())
@@ -181,7 +192,7 @@ func TestRoutesSetupWithoutPanic(t *testing.T) {
 	router := gin.New()
 	
 	// Pass nil values to trigger an error condition
-	err := SetupRoutes(router, nil, nil)
+	_, err := SetupRoutes(router, nil, nil, nil)
 	
 	// Should return an error, not panic
 	if err == nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
 // LeadsHandler handles lead filtering and export operations
@@ -18,9 +19,9 @@ type LeadsHandler struct {
 }
 
 // NewLeadsHandler creates a new leads handler
-func NewLeadsHandler(db *sql.DB, scoringService services.ScoringService) *LeadsHandler {
+func NewLeadsHandler(db *sql.DB, scoringService services.ScoringService, cfg *config.Config) *LeadsHandler {
 	return &LeadsHandler{
-		leadExportService: services.NewLeadExportService(db, scoringService),
+		leadExportService: services.NewLeadExportServiceWithMinScore(db, scoringService, cfg.LeadRequiredMinScore),
 	}
 }
 
@@ -36,18 +37,35 @@ func (h *LeadsHandler) GetQualifiedLeads(c *gin.Context) {
 		return
 	}
 
+	// On this endpoint ?limit= is the page size, so the page replaces any filter limit
+	pagination := parsePagination(c)
+	pageSize, offset := pagination.PageSize, pagination.Offset()
+	filter.Limit = &pageSize
+	filter.Offset = &offset
+
+	total, err := h.leadExportService.CountQualifiedLeads(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get qualified leads: " + err.Error()})
+		return
+	}
+
 	leads, err := h.leadExportService.GetQualifiedLeads(filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get qualified leads: " + err.Error()})
 		return
 	}
+	if leads == nil {
+		leads = []services.QualifiedLead{}
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"leads":     leads,
-		"count":     len(leads),
-		"filter":    filter,
-		"timestamp": time.Now(),
-	})
+	c.JSON(http.StatusOK, newPaginatedResponse(leads, pagination, total))
+}
+
+// ExportLeadsRequest is the JSON body accepted by ExportQualifiedLeads: the lead
+// filter plus an optional export format
+type ExportLeadsRequest struct {
+	services.LeadFilter
+	Format string `json:"format"`
 }
 
 // ExportQualifiedLeads exports qualified leads in the specified format
@@ -55,37 +73,41 @@ func (h *LeadsHandler) ExportQualifiedLeads(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	// Parse filter from request body or query
-	var filter services.LeadFilter
-	if err := c.ShouldBindJSON(&filter); err != nil {
-		// Fallback to query parameters
-		var parseErr error
-		filter, parseErr = h.parseFilterFromQuery(c)
-		if parseErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameters: " + parseErr.Error()})
+	// Parse filter from request body, or from the query when there is no body
+	var req ExportLeadsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+	} else {
+		filter, err := h.parseFilterFromQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameters: " + err.Error()})
 			return
 		}
+		req.LeadFilter = filter
+	}
+	filter := req.LeadFilter
+
+	// The query format takes precedence over the body; either must name a supported format
+	formatName := req.Format
+	if format, ok := c.GetQuery("format"); ok {
+		formatName = format
+	}
+	format, err := services.ParseExportFormat(formatName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	// Parse export options
 	options := services.LeadExportOptions{
-		Format:                services.FormatJSON, // Default to JSON
+		Format:                format,
 		IncludeScoreBreakdown: false,
 		IncludeMetadata:       true,
 	}
 
-	if format := c.Query("format"); format != "" {
-		switch strings.ToLower(format) {
-		case "csv":
-			options.Format = services.FormatCSV
-		case "json":
-			options.Format = services.FormatJSON
-		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Supported formats: json, csv"})
-			return
-		}
-	}
-
 	if includeBreakdown := c.Query("include_breakdown"); includeBreakdown == "true" {
 		options.IncludeScoreBreakdown = true
 	}
@@ -94,6 +116,10 @@ func (h *LeadsHandler) ExportQualifiedLeads(c *gin.Context) {
 		options.IncludeMetadata = false
 	}
 
+	if dedupe := c.Query("dedupe_by_company"); dedupe == "true" {
+		options.DedupeByCompany = true
+	}
+
 	// Export leads
 	data, err := h.leadExportService.ExportQualifiedLeads(filter, options)
 	if err != nil {
@@ -111,6 +137,9 @@ func (h *LeadsHandler) ExportQualifiedLeads(c *gin.Context) {
 	case services.FormatJSON:
 		c.Header("Content-Type", "application/json")
 		c.Header("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+	case services.FormatVCard:
+		c.Header("Content-Type", "text/vcard")
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`.vcf"`)
 	}
 
 	c.Data(http.StatusOK, c.GetHeader("Content-Type"), data)
@@ -131,8 +160,18 @@ func (h *LeadsHandler) GetLeadStats(c *gin.Context) {
 		return
 	}
 
+	// Risk indicator facets come from the normalized index rather than the loaded leads
+	riskCounts, err := h.leadExportService.CountRiskIndicators(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get lead statistics: " + err.Error()})
+		return
+	}
+
 	// Calculate statistics
 	stats := h.calculateLeadStats(leads)
+	if len(leads) > 0 {
+		stats["common_risk_indicators"] = riskCounts
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"stats":     stats,
@@ -141,6 +180,37 @@ func (h *LeadsHandler) GetLeadStats(c *gin.Context) {
 	})
 }
 
+// RefreshInsights recomputes and stores business insights for matching leads (Admin only)
+func (h *LeadsHandler) RefreshInsights(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	// Filter is optional; an empty filter refreshes every scored company
+	filter, err := h.parseFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameters: " + err.Error()})
+		return
+	}
+
+	result, err := h.leadExportService.RefreshInsights(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh insights: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Insights refreshed",
+		"result":  result,
+	})
+}
+
 // GetLeadByTicker returns detailed information about a specific company lead
 func (h *LeadsHandler) GetLeadByTicker(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -201,6 +271,11 @@ func (h *LeadsHandler) parseFilterFromQuery(c *gin.Context) (services.LeadFilter
 		filter.MarketTiers = strings.Split(tiers, ",")
 	}
 
+	// Parse risk indicators
+	if indicators := c.Query("risk_indicators"); indicators != "" {
+		filter.RiskIndicators = strings.Split(indicators, ",")
+	}
+
 	// Parse quote statuses
 	if statuses := c.Query("quote_statuses"); statuses != "" {
 		filter.QuoteStatuses = strings.Split(statuses, ",")
@@ -251,11 +326,49 @@ func (h *LeadsHandler) parseFilterFromQuery(c *gin.Context) (services.LeadFilter
 		}
 	}
 
+	if hasContactInfo := c.Query("has_contact_info"); hasContactInfo != "" {
+		if parsed, err := strconv.ParseBool(hasContactInfo); err == nil {
+			filter.HasContactInfo = &parsed
+		}
+	}
+
+	// Parse tier change recency
+	if minDays := c.Query("min_days_since_tier_change"); minDays != "" {
+		if parsed, err := strconv.Atoi(minDays); err == nil {
+			filter.MinDaysSinceTierChange = &parsed
+		}
+	}
+
+	if maxDays := c.Query("max_days_since_tier_change"); maxDays != "" {
+		if parsed, err := strconv.Atoi(maxDays); err == nil {
+			filter.MaxDaysSinceTierChange = &parsed
+		}
+	}
+
+	if qualifiedAfter := c.Query("qualified_after"); qualifiedAfter != "" {
+		if parsed, err := time.Parse("2006-01-02", qualifiedAfter); err == nil {
+			filter.QualifiedAfter = &parsed
+		}
+	}
+
+	if qualifiedBefore := c.Query("qualified_before"); qualifiedBefore != "" {
+		if parsed, err := time.Parse("2006-01-02", qualifiedBefore); err == nil {
+			// Include the whole of the named day
+			end := parsed.AddDate(0, 0, 1)
+			filter.QualifiedBefore = &end
+		}
+	}
+
 	// Parse other options
 	if includeRequiredOnly := c.Query("include_required_only"); includeRequiredOnly == "true" {
 		filter.IncludeRequiredOnly = true
 	}
 
+	if useStored := c.Query("use_stored_insights"); useStored == "true" {
+		filter.UseStoredInsights = true
+	}
+
+	// Caps exports and stats; GetQualifiedLeads reads ?limit= as its page size instead
 	if limit := c.Query("limit"); limit != "" {
 		if parsed, err := strconv.Atoi(limit); err == nil {
 			filter.Limit = &parsed
@@ -301,15 +414,6 @@ func (h *LeadsHandler) calculateLeadStats(leads []services.QualifiedLead) map[st
 	stats["min_score"] = minScore
 	stats["max_score"] = maxScore
 
-	// Risk indicator analysis
-	riskCounts := make(map[string]int)
-	for _, lead := range leads {
-		for _, risk := range lead.RiskIndicators {
-			riskCounts[risk]++
-		}
-	}
-	stats["common_risk_indicators"] = riskCounts
-
 	// Service recommendations
 	serviceCounts := make(map[string]int)
 	for _, lead := range leads {
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page size bounds shared by all list endpoints
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// PaginatedResponse is the response envelope shared by all list endpoints
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	Total      int         `json:"total"`
+	TotalPages int         `json:"total_pages"`
+}
+
+// Pagination is the page a client requested
+type Pagination struct {
+	Page     int
+	PageSize int
+}
+
+// parsePagination reads ?page= and ?page_size= (or the older ?limit=),
+// falling back to the first page of defaultPageSize for invalid values
+func parsePagination(c *gin.Context) Pagination {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	sizeStr := c.Query("page_size")
+	if sizeStr == "" {
+		sizeStr = c.Query("limit")
+	}
+	pageSize, err := strconv.Atoi(sizeStr)
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	return Pagination{Page: page, PageSize: pageSize}
+}
+
+// Offset returns the index of the first item on the page
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// newPaginatedResponse wraps one page of data with its position in the full result
+func newPaginatedResponse(data interface{}, p Pagination, total int) PaginatedResponse {
+	return PaginatedResponse{
+		Data:       data,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		Total:      total,
+		TotalPages: (total + p.PageSize - 1) / p.PageSize,
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scraper"
+	"github.com/gin-gonic/gin"
+)
+
+// ParserHandler exposes on-demand parser previews for diagnosing extraction issues
+type ParserHandler struct {
+	scraperService *scraper.Service
+}
+
+// NewParserHandler creates a new parser handler
+func NewParserHandler(scraperService *scraper.Service) *ParserHandler {
+	return &ParserHandler{scraperService: scraperService}
+}
+
+// ParseURLRequest represents a request to preview parser output for a URL
+type ParseURLRequest struct {
+	URL      string `json:"url" binding:"required"`
+	PageType string `json:"page_type"` // overview, financials or disclosure; inferred from the URL when empty
+}
+
+// ParseURL fetches an OTC Markets URL and returns the parser's extracted fields (Admin only)
+func (h *ParserHandler) ParseURL(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	var req ParseURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if _, err := scraper.ResolvePageType(req.URL, req.PageType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	preview, err := h.scraperService.ParseURL(ctx, req.URL, req.PageType)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch or parse URL: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview":   preview,
+		"timestamp": time.Now(),
+	})
+}
@@ -136,6 +136,10 @@ func (h *PipelineHandler) RunPipelineOnce(c *gin.Context) {
 		}
 	}
 
+	if prioritize := c.Query("prioritize_rescraped"); prioritize == "true" {
+		config.PrioritizeRescraped = true
+	}
+
 	// Execute one-time scoring
 	stats, err := h.pipeline.RunOnce(config)
 	if err != nil {
@@ -151,6 +155,22 @@ func (h *PipelineHandler) RunPipelineOnce(c *gin.Context) {
 	})
 }
 
+// GetPipelineRuns returns recorded scoring cycles, newest first
+func (h *PipelineHandler) GetPipelineRuns(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pagination := parsePagination(c)
+
+	runs, total, err := h.pipeline.GetRecentRuns(ctx, pagination.PageSize, pagination.Offset())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get pipeline runs: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(runs, pagination, total))
+}
+
 // GetPipelineConfig returns the default pipeline configuration
 func (h *PipelineHandler) GetPipelineConfig(c *gin.Context) {
 	config := services.DefaultPipelineConfig()
@@ -163,6 +183,7 @@ func (h *PipelineHandler) GetPipelineConfig(c *gin.Context) {
 			"max_concurrent":         "Maximum number of concurrent scoring operations",
 			"process_new_only":       "Only process companies that have never been scored",
 			"rescore_older_than_days": "Rescore companies that were scored more than X days ago",
+			"prioritize_rescraped":   "Rescore companies scraped since their last score first",
 		},
 		"timestamp": time.Now(),
 	})
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler exposes the history retention cleanup
+type RetentionHandler struct {
+	retention *services.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retention *services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{retention: retention}
+}
+
+// RunCleanup prunes old history immediately (Admin only)
+func (h *RetentionHandler) RunCleanup(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	if !h.retention.Enabled() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retention is disabled; set HISTORY_RETENTION_DAYS to enable it"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := h.retention.RunCleanup(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Retention cleanup failed: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Retention cleanup completed",
+		"result":  result,
+	})
+}
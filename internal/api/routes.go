@@ -8,33 +8,82 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/auth"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/database"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/middleware"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scraper"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
 	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
-// SetupRoutes configures all API routes
-func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
+// BackgroundJobs are the long-running services behind the API routes. The caller
+// starts them once the routes are set up and stops them on shutdown.
+type BackgroundJobs struct {
+	Retention           *services.RetentionService
+	RequirementsSweeper *services.RequirementsSweeper
+}
+
+// Start starts every enabled background job
+func (b *BackgroundJobs) Start() error {
+	if b.Retention.Enabled() {
+		if err := b.Retention.Start(); err != nil {
+			return fmt.Errorf("failed to start retention cleanup: %w", err)
+		}
+	}
+	if b.RequirementsSweeper.Enabled() {
+		if err := b.RequirementsSweeper.Start(); err != nil {
+			return fmt.Errorf("failed to start requirements sweeper: %w", err)
+		}
+	}
+	return nil
+}
+
+// Stop stops every enabled background job, waiting for in-flight work to finish
+func (b *BackgroundJobs) Stop() {
+	if b.Retention.Enabled() {
+		if err := b.Retention.Stop(); err != nil {
+			log.Printf("Error stopping retention cleanup: %v", err)
+		}
+	}
+	if b.RequirementsSweeper.Enabled() {
+		if err := b.RequirementsSweeper.Stop(); err != nil {
+			log.Printf("Error stopping requirements sweeper: %v", err)
+		}
+	}
+}
+
+// SetupRoutes configures all API routes and registers background-job gauges on
+// metrics, returning the background jobs for the caller to start and stop
+func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config, metrics *middleware.Metrics) (*BackgroundJobs, error) {
 	// Wrap sql.DB in our database wrapper
 	dbWrapper := &database.DB{DB: db}
 	
 	// Create services
 	scraperService, err := scraper.NewService(dbWrapper, cfg, 5) // 5 concurrent scrapers
 	if err != nil {
-		return fmt.Errorf("failed to create scraper service: %w", err)
+		return nil, fmt.Errorf("failed to create scraper service: %w", err)
 	}
 	
+	// Background history retention, run when a retention period is configured
+	retentionService := services.NewRetentionService(db, cfg)
+	
 	// Create centralized services
 	services := services.NewServices(db, cfg)
 	
+	// Rescore scores stored without requirements_met in the background
+	requirementsSweeper := services.RequirementsSweeper
+	metrics.RegisterGauge("company_scores_requirements_unknown",
+		"Stored scores whose requirements_met has not been recomputed (-1 before the first sweep).",
+		func() float64 { return float64(requirementsSweeper.Remaining()) })
+	
 	// Create handlers with proper service injection
-	uploadHandler := NewUploadHandler(scraperService)
+	uploadHandler := NewUploadHandler(scraperService, cfg)
 	authHandler := NewAuthHandler(db, cfg)            // Legacy handler
 	authHandlerV2 := NewAuthHandlerV2(services.Auth)  // New service-based handler
 	scoringHandler := NewScoringHandler(db)           // Legacy handler
 	scoringHandlerV2 := NewScoringHandlerV2(services.Scoring) // New service-based handler
 	pipelineHandler := NewPipelineHandler(db)         // TODO: Migrate to service layer
-	leadsHandler := NewLeadsHandler(db, services.Scoring) // Using scoring service
+	leadsHandler := NewLeadsHandler(db, services.Scoring, cfg) // Using scoring service
+	retentionHandler := NewRetentionHandler(retentionService)
+	parserHandler := NewParserHandler(scraperService)
 	
 	// Public routes
 	public := r.Group("/api/v1")
@@ -48,17 +97,24 @@ func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
 	
 	// Protected routes
 	protected := r.Group("/api/v1")
-	protected.Use(auth.JWTMiddleware(cfg.JWTSecret))
+	protected.Use(auth.JWTMiddlewareWithKeys(auth.KeySetFromConfig(cfg)))
 	protected.Use(auth.CSRFMiddleware())
 	{
 		// CSV Upload endpoints
 		protected.POST("/upload/csv", uploadHandler.UploadCSV)
+		protected.POST("/upload/cik", uploadHandler.ImportByCIK)
+		protected.GET("/scrape/template.csv", uploadHandler.GetCSVTemplate)
 		protected.GET("/jobs", uploadHandler.GetJobs)
 		protected.GET("/jobs/:id", uploadHandler.GetJob)
 		
 		// Company endpoints
 		protected.GET("/companies", uploadHandler.GetCompanies)
+		protected.GET("/companies/batch", uploadHandler.GetCompaniesBatch)
+		protected.GET("/companies/by-score", uploadHandler.GetCompaniesByScore)
+		protected.GET("/companies/facets", uploadHandler.GetCompanyFacets)
+		protected.POST("/companies/batch", uploadHandler.GetCompaniesBatch)
 		protected.GET("/companies/:ticker", uploadHandler.GetCompany)
+		protected.POST("/companies/:ticker/rescrape", uploadHandler.RescrapeCompany)
 		
 		// Health monitoring endpoints
 		protected.GET("/health", uploadHandler.GetSystemHealth)
@@ -68,6 +124,7 @@ func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
 		// Scoring endpoints - using new service-based handlers
 		protected.GET("/scoring/models", scoringHandlerV2.GetScoringModels)
 		protected.GET("/scoring/models/:id", scoringHandlerV2.GetScoringModel)
+		protected.GET("/scoring/models/:id/explain", scoringHandlerV2.ExplainScoringModel)
 		protected.POST("/scoring/models", scoringHandlerV2.CreateScoringModel)
 		protected.PUT("/scoring/models/:id", scoringHandlerV2.UpdateScoringModel)
 		protected.DELETE("/scoring/models/:id", scoringHandlerV2.DeleteScoringModel)
@@ -75,7 +132,9 @@ func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
 		// Company scoring endpoints
 		protected.POST("/scoring/companies/:id/score", scoringHandlerV2.ScoreCompany)
 		protected.GET("/scoring/companies/:id/scores", scoringHandlerV2.GetCompanyScores)
+		protected.GET("/scoring/companies/:id/summary", scoringHandlerV2.GetCompanyScoreSummary)
 		protected.POST("/scoring/companies/:id/score/:model_id", scoringHandlerV2.ScoreCompanyWithModel)
+		protected.POST("/scoring/companies/:id/evaluate/:model_id", scoringHandlerV2.EvaluateCompanyWithModel)
 		
 		// Bulk scoring endpoints
 		protected.POST("/scoring/models/:id/score-all", scoringHandlerV2.ScoreAllCompanies)
@@ -83,6 +142,7 @@ func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
 		// Automated pipeline endpoints
 		protected.GET("/pipeline/status", pipelineHandler.GetPipelineStatus)
 		protected.GET("/pipeline/config", pipelineHandler.GetPipelineConfig)
+		protected.GET("/pipeline/runs", pipelineHandler.GetPipelineRuns)
 		protected.POST("/pipeline/start", pipelineHandler.StartPipeline)
 		protected.POST("/pipeline/stop", pipelineHandler.StopPipeline)
 		protected.POST("/pipeline/run-once", pipelineHandler.RunPipelineOnce)
@@ -91,8 +151,20 @@ func SetupRoutes(r *gin.Engine, db *sql.DB, cfg *config.Config) error {
 		protected.GET("/leads", leadsHandler.GetQualifiedLeads)
 		protected.POST("/leads/export", leadsHandler.ExportQualifiedLeads)
 		protected.GET("/leads/stats", leadsHandler.GetLeadStats)
+		protected.POST("/leads/insights/refresh", leadsHandler.RefreshInsights)
 		protected.GET("/leads/:ticker", leadsHandler.GetLeadByTicker)
+		
+		// Admin endpoints
+		protected.GET("/admin/scoring-config/export", scoringHandlerV2.ExportScoringConfig)
+		protected.POST("/admin/scoring-config/import", scoringHandlerV2.ImportScoringConfig)
+		protected.POST("/admin/retention/cleanup", retentionHandler.RunCleanup)
+		protected.POST("/admin/parse-url", parserHandler.ParseURL)
+		protected.POST("/admin/retransform", uploadHandler.RetransformCompanies)
+		protected.GET("/admin/credit-usage", uploadHandler.GetCreditUsage)
 	}
 	
-	return nil
+	return &BackgroundJobs{
+		Retention:           retentionService,
+		RequirementsSweeper: requirementsSweeper,
+	}, nil
 }
\ No newline at end of file
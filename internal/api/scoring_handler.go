@@ -181,10 +181,18 @@ func (h *ScoringHandler) ScoreCompany(c *gin.Context) {
 
 	companyID := c.Param("id")
 
-	if err := h.scoringService.ScoreCompany(companyID); err != nil {
+	result, err := h.scoringService.ScoreCompany(companyID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to score company: " + err.Error()})
 		return
 	}
+	if result.Succeeded == 0 && result.Failed > 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to score company: no model produced a score",
+			"scoring": result,
+		})
+		return
+	}
 
 	// Get the updated scores
 	scores, err := h.scoringService.GetCompanyScores(companyID)
@@ -193,9 +201,16 @@ func (h *ScoringHandler) ScoreCompany(c *gin.Context) {
 		return
 	}
 
+	message := "Company scored successfully"
+	if result.Partial() {
+		message = "Company " + result.Summary()
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "Company scored successfully",
+		"message":   message,
 		"company_id": companyID,
+		"partial":   result.Partial(),
+		"scoring":   result,
 		"scores":    scores,
 		"timestamp": time.Now(),
 	})
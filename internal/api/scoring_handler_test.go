@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
 )
 
 // Mock scoring service for testing
@@ -75,11 +76,11 @@ func (m *mockScoringService) DeleteScoringModel(modelID string) error {
 	return errors.New("scoring model " + modelID + " not found")
 }
 
-func (m *mockScoringService) ScoreCompany(companyID string) error {
+func (m *mockScoringService) ScoreCompany(companyID string) (*services.CompanyScoringResult, error) {
 	if m.shouldError {
-		return errors.New("mock error")
+		return nil, errors.New("mock error")
 	}
-	return nil
+	return &services.CompanyScoringResult{CompanyID: companyID}, nil
 }
 
 func (m *mockScoringService) GetCompanyScores(companyID string) ([]scoring.ScoreResult, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -66,6 +67,26 @@ func (h *ScoringHandlerV2) GetScoringModel(c *gin.Context) {
 	})
 }
 
+// ExplainScoringModel returns a plain-English summary of what a model looks for
+func (h *ScoringHandlerV2) ExplainScoringModel(c *gin.Context) {
+	modelID := c.Param("id")
+
+	explanation, err := h.scoringService.ExplainScoringModel(modelID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "scoring model "+modelID+" not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scoring model not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to explain scoring model: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"explanation": explanation,
+		"timestamp":   time.Now(),
+	})
+}
+
 // CreateScoringModel creates a new ICP scoring model (Admin only)
 func (h *ScoringHandlerV2) CreateScoringModel(c *gin.Context) {
 	// Check admin role
@@ -179,10 +200,18 @@ func (h *ScoringHandlerV2) ScoreCompany(c *gin.Context) {
 
 	companyID := c.Param("id")
 
-	if err := h.scoringService.ScoreCompany(companyID); err != nil {
+	result, err := h.scoringService.ScoreCompany(companyID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to score company: " + err.Error()})
 		return
 	}
+	if result.Succeeded == 0 && result.Failed > 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to score company: no model produced a score",
+			"scoring": result,
+		})
+		return
+	}
 
 	// Get the updated scores
 	scores, err := h.scoringService.GetCompanyScores(companyID)
@@ -191,9 +220,16 @@ func (h *ScoringHandlerV2) ScoreCompany(c *gin.Context) {
 		return
 	}
 
+	message := "Company scored successfully"
+	if result.Partial() {
+		message = "Company " + result.Summary()
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "Company scored successfully",
+		"message":   message,
 		"company_id": companyID,
+		"partial":   result.Partial(),
+		"scoring":   result,
 		"scores":    scores,
 		"timestamp": time.Now(),
 	})
@@ -205,16 +241,34 @@ func (h *ScoringHandlerV2) GetCompanyScores(c *gin.Context) {
 	defer cancel()
 
 	companyID := c.Param("id")
+	pagination := parsePagination(c)
 
-	scores, err := h.scoringService.GetCompanyScores(companyID)
+	scores, total, err := h.scoringService.GetCompanyScoresPage(companyID, pagination.PageSize, pagination.Offset())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get company scores: " + err.Error()})
 		return
 	}
 
+	c.JSON(http.StatusOK, newPaginatedResponse(scores, pagination, total))
+}
+
+// GetCompanyScoreSummary returns only the triggered rules and totals per model
+func (h *ScoringHandlerV2) GetCompanyScoreSummary(c *gin.Context) {
+	companyID := c.Param("id")
+
+	summaries, err := h.scoringService.GetCompanyScoreSummary(companyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid company ID") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get score summary: " + err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"company_id": companyID,
-		"scores":     scores,
+		"summaries":  summaries,
 		"timestamp":  time.Now(),
 	})
 }
@@ -242,6 +296,51 @@ func (h *ScoringHandlerV2) ScoreCompanyWithModel(c *gin.Context) {
 	})
 }
 
+// EvaluateCompanyRequest carries per-request tuning for an evaluation
+type EvaluateCompanyRequest struct {
+	WeightOverrides map[string]int `json:"weight_overrides"`
+	// SnapshotID scores the company_history snapshot with this ID instead of the current row
+	SnapshotID string `json:"snapshot_id"`
+}
+
+// EvaluateCompanyWithModel scores a company against a model without storing the result,
+// optionally overriding rule weights or scoring a stored snapshot for this evaluation only
+func (h *ScoringHandlerV2) EvaluateCompanyWithModel(c *gin.Context) {
+	companyID := c.Param("id")
+	modelID := c.Param("model_id")
+
+	var req EvaluateCompanyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+	}
+
+	result, err := h.scoringService.EvaluateCompanyWithModel(companyID, modelID, req.WeightOverrides, req.SnapshotID)
+	if err != nil {
+		if strings.Contains(err.Error(), "weight overrides") || strings.Contains(err.Error(), "invalid snapshot ID") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.SnapshotID != "" && strings.HasSuffix(err.Error(), "snapshot "+req.SnapshotID+" not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Snapshot not found for company"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate company: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"company_id":       companyID,
+		"model_id":         modelID,
+		"weight_overrides": req.WeightOverrides,
+		"snapshot_id":      req.SnapshotID,
+		"result":           result,
+		"timestamp":        time.Now(),
+	})
+}
+
 // ScoreAllCompanies scores all companies against a specific ICP model (Admin only)
 func (h *ScoringHandlerV2) ScoreAllCompanies(c *gin.Context) {
 	// Check admin role
@@ -266,4 +365,72 @@ func (h *ScoringHandlerV2) ScoreAllCompanies(c *gin.Context) {
 		"model_id":  modelID,
 		"timestamp": time.Now(),
 	})
-}
\ No newline at end of file
+}
+// ExportScoringConfig returns a versioned bundle of the full scoring configuration (Admin only)
+func (h *ScoringHandlerV2) ExportScoringConfig(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	bundle, err := h.scoringService.ExportScoringConfig()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export scoring config: " + err.Error()})
+		return
+	}
+
+	filename := "scoring_config_" + bundle.ExportedAt.Format("20060102_150405") + ".json"
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportScoringConfig restores a scoring configuration bundle (Admin only).
+// Pass ?dry_run=true to report the changes without applying them.
+func (h *ScoringHandlerV2) ImportScoringConfig(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	// Get user ID
+	userID, exists := c.Get(auth.UserIDKey)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var bundle services.ScoringConfigBundle
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid config bundle: " + err.Error()})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.scoringService.ImportScoringConfig(&bundle, userUUID.String(), dryRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to import scoring config: " + err.Error()})
+		return
+	}
+
+	message := "Scoring config imported successfully"
+	if dryRun {
+		message = "Dry run completed, no changes applied"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   message,
+		"report":    report,
+		"timestamp": time.Now(),
+	})
+}
@@ -15,23 +15,34 @@ import (
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/auth"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scraper"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
 // UploadHandler handles CSV upload and scraping operations
 type UploadHandler struct {
 	scraperService *scraper.Service
+	cfg            *config.Config
 }
 
 // NewUploadHandler creates a new upload handler
-func NewUploadHandler(scraperService *scraper.Service) *UploadHandler {
+func NewUploadHandler(scraperService *scraper.Service, cfg *config.Config) *UploadHandler {
 	return &UploadHandler{
 		scraperService: scraperService,
+		cfg:            cfg,
 	}
 }
 
+// maxJobTickers returns the job size limit for the requesting user's role
+func (h *UploadHandler) maxJobTickers(c *gin.Context) int {
+	role, _ := c.Get("user_role")
+	roleStr, _ := role.(string)
+	return h.cfg.MaxJobTickers(roleStr)
+}
+
 // UploadCSVRequest represents the CSV upload request
 type UploadCSVRequest struct {
-	UseOptimized bool `json:"use_optimized" form:"use_optimized"`
+	UseOptimized bool   `json:"use_optimized" form:"use_optimized"`
+	Priority     string `json:"priority" form:"priority"` // high, normal (default) or low
 }
 
 // UploadCSV handles CSV file upload and queues scraping job
@@ -47,6 +58,12 @@ func (h *UploadHandler) UploadCSV(c *gin.Context) {
 		return
 	}
 
+	priority, err := scraper.ParsePriority(req.Priority)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Get uploaded file
 	file, header, err := c.Request.FormFile("csv_file")
 	if err != nil {
@@ -74,8 +91,8 @@ func (h *UploadHandler) UploadCSV(c *gin.Context) {
 	}
 
 	// Validate ticker count (prevent overload)
-	if len(tickers) > 10000 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many tickers. Maximum 10,000 allowed per upload"})
+	if maxTickers := h.maxJobTickers(c); len(tickers) > maxTickers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many tickers. Maximum %d allowed per upload", maxTickers)})
 		return
 	}
 
@@ -93,7 +110,7 @@ func (h *UploadHandler) UploadCSV(c *gin.Context) {
 	}
 
 	// Create and start scraping job with transaction safety
-	job, err := h.scraperService.ScrapeTickersBatch(ctx, tickers, userUUID, req.UseOptimized)
+	job, err := h.scraperService.ScrapeTickersBatch(ctx, tickers, userUUID, req.UseOptimized, priority)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to start scraping job: %v", err)})
 		return
@@ -104,10 +121,159 @@ func (h *UploadHandler) UploadCSV(c *gin.Context) {
 		"job_id":        job.ID,
 		"total_tickers": len(tickers),
 		"status":        job.Status,
+		"priority":      job.Priority,
 		"filename":      header.Filename,
 	})
 }
 
+// ImportCIKRequest represents a request to import companies by SEC CIK
+type ImportCIKRequest struct {
+	CIKs         []string `json:"ciks" binding:"required"`
+	UseOptimized bool     `json:"use_optimized"`
+	Priority     string   `json:"priority"` // high, normal (default) or low
+}
+
+// ImportByCIK resolves CIKs to tickers via EDGAR and queues a scraping job for them
+func (h *UploadHandler) ImportByCIK(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var req ImportCIKRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if len(req.CIKs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one CIK is required"})
+		return
+	}
+
+	priority, err := scraper.ParsePriority(req.Priority)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if maxTickers := h.maxJobTickers(c); len(req.CIKs) > maxTickers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many CIKs. Maximum %d allowed per import", maxTickers)})
+		return
+	}
+
+	userID, exists := c.Get(auth.UserIDKey)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	result, err := h.scraperService.ImportByCIK(ctx, req.CIKs, userUUID, req.UseOptimized, priority)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": fmt.Sprintf("Failed to import CIKs: %v", err)})
+		return
+	}
+
+	if result.Job == nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":      "None of the CIKs could be resolved to a ticker",
+			"unresolved": result.Unresolved,
+			"invalid":    result.Invalid,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":       "CIK import started",
+		"job_id":        result.Job.ID,
+		"total_tickers": result.Job.TotalTickers,
+		"status":        result.Job.Status,
+		"priority":      result.Job.Priority,
+		"resolved":      result.Resolved,
+		"unresolved":    result.Unresolved,
+		"invalid":       result.Invalid,
+	})
+}
+
+// RetransformRequest represents a request to rebuild and re-score companies from stored scrapes
+type RetransformRequest struct {
+	Tickers []string `json:"tickers" binding:"required"`
+}
+
+// RetransformCompanies rebuilds companies from the parsed fields of their latest stored
+// scrape with the current transformer and re-scores them in one job, without new OxyLabs
+// requests (Admin only). Parser changes need a rescrape.
+func (h *UploadHandler) RetransformCompanies(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var req RetransformRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	var tickers []string
+	seen := make(map[string]bool)
+	for _, raw := range req.Tickers {
+		ticker := strings.ToUpper(strings.TrimSpace(raw))
+		if !h.isValidTicker(ticker) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ticker format '%s'", raw)})
+			return
+		}
+		if !seen[ticker] {
+			seen[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+
+	if len(tickers) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one ticker is required"})
+		return
+	}
+
+	if maxTickers := h.maxJobTickers(c); len(tickers) > maxTickers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many tickers. Maximum %d allowed per re-transform job", maxTickers)})
+		return
+	}
+
+	userID, exists := c.Get(auth.UserIDKey)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	job, err := h.scraperService.RetransformCompanies(ctx, tickers, userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to start re-transform job: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":       "Re-transform job started",
+		"job_id":        job.ID,
+		"total_tickers": job.TotalTickers,
+		"status":        job.Status,
+	})
+}
+
 // parseCSV extracts tickers from CSV file
 func (h *UploadHandler) parseCSV(file io.Reader) ([]string, error) {
 	reader := csv.NewReader(file)
@@ -154,6 +320,15 @@ func (h *UploadHandler) parseCSV(file io.Reader) ([]string, error) {
 	return tickers, nil
 }
 
+// csvTemplate is the sample upload file: a ticker header followed by one ticker per row
+const csvTemplate = "ticker\nABCD\nWXYZ\n"
+
+// GetCSVTemplate serves a sample CSV in the format UploadCSV accepts
+func (h *UploadHandler) GetCSVTemplate(c *gin.Context) {
+	c.Header("Content-Disposition", `attachment; filename="ticker_upload_template.csv"`)
+	c.Data(http.StatusOK, "text/csv", []byte(csvTemplate))
+}
+
 // isValidTicker performs basic ticker validation
 func (h *UploadHandler) isValidTicker(ticker string) bool {
 	// Basic validation: 1-10 characters, alphanumeric only
@@ -188,13 +363,15 @@ func (h *UploadHandler) GetJobs(c *gin.Context) {
 		return
 	}
 
-	jobs, err := h.scraperService.GetUserJobs(ctx, userUUID)
+	pagination := parsePagination(c)
+
+	jobs, total, err := h.scraperService.GetUserJobs(ctx, userUUID, pagination.PageSize, pagination.Offset())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch jobs: %v", err)})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+	c.JSON(http.StatusOK, newPaginatedResponse(jobs, pagination, total))
 }
 
 // GetJob returns a specific scraping job
@@ -218,42 +395,143 @@ func (h *UploadHandler) GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"job": job})
 }
 
+// GetCreditUsage reports OxyLabs requests consumed by scrape jobs started between
+// ?from= and ?to= (YYYY-MM-DD, both inclusive), totalled and by day
+func (h *UploadHandler) GetCreditUsage(c *gin.Context) {
+	// Check admin role
+	role, exists := c.Get("user_role")
+	if !exists || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	var from, to *time.Time
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid from date '%s'; expected YYYY-MM-DD", raw)})
+			return
+		}
+		from = &parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid to date '%s'; expected YYYY-MM-DD", raw)})
+			return
+		}
+		// Include the whole of the last day
+		end := parsed.AddDate(0, 0, 1)
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from date cannot be after to date"})
+		return
+	}
+
+	usage, err := h.scraperService.GetCreditUsage(ctx, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch credit usage: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":      c.Query("from"),
+		"to":        c.Query("to"),
+		"jobs":      usage.Jobs,
+		"tickers":   usage.Tickers,
+		"requests":  usage.Requests,
+		"daily":     usage.Daily,
+		"timestamp": time.Now(),
+	})
+}
+
 // GetCompanies returns paginated company data
 func (h *UploadHandler) GetCompanies(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
 	// Parse query parameters
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "50")
+	pagination := parsePagination(c)
 	search := c.Query("search")
 	marketTier := c.Query("market_tier")
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+	// Get companies from service
+	companies, total, err := h.scraperService.GetCompanies(ctx, pagination.Page, pagination.PageSize, search, marketTier)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch companies: %v", err)})
+		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 1000 {
-		limit = 50
+	c.JSON(http.StatusOK, newPaginatedResponse(companies, pagination, total))
+}
+
+// GetCompaniesByScore returns companies whose best score is within ?min= and ?max=,
+// optionally restricted to one model with ?model_id=
+func (h *UploadHandler) GetCompaniesByScore(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	pagination := parsePagination(c)
+
+	var minScore, maxScore *int
+	if raw := c.Query("min"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid min score '%s'", raw)})
+			return
+		}
+		minScore = &parsed
+	}
+	if raw := c.Query("max"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid max score '%s'", raw)})
+			return
+		}
+		maxScore = &parsed
+	}
+	if minScore != nil && maxScore != nil && *minScore > *maxScore {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min score cannot exceed max score"})
+		return
 	}
 
-	// Get companies from service
-	companies, total, err := h.scraperService.GetCompanies(ctx, page, limit, search, marketTier)
+	modelID := c.Query("model_id")
+	if modelID != "" {
+		if _, err := uuid.Parse(modelID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid model ID '%s'", modelID)})
+			return
+		}
+	}
+
+	companies, total, err := h.scraperService.GetCompaniesByScore(ctx, minScore, maxScore, modelID, pagination.Page, pagination.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch companies: %v", err)})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch companies by score: %v", err)})
 		return
 	}
 
-	totalPages := (total + limit - 1) / limit
+	c.JSON(http.StatusOK, newPaginatedResponse(companies, pagination, total))
+}
+
+// GetCompanyFacets returns the distinct market tiers and quote statuses present,
+// with counts, for populating filter dropdowns
+func (h *UploadHandler) GetCompanyFacets(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	facets, err := h.scraperService.GetCompanyFacets(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch company facets: %v", err)})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"companies":   companies,
-		"page":        page,
-		"limit":       limit,
-		"total":       total,
-		"total_pages": totalPages,
+		"market_tiers":   facets.MarketTiers,
+		"quote_statuses": facets.QuoteStatuses,
+		"timestamp":      time.Now(),
 	})
 }
 
@@ -278,6 +556,110 @@ func (h *UploadHandler) GetCompany(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"company": company})
 }
 
+// RescrapeCompany synchronously rescrapes a single ticker, subject to a per-ticker cooldown
+func (h *UploadHandler) RescrapeCompany(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	ticker := strings.ToUpper(c.Param("ticker"))
+	if !h.isValidTicker(ticker) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ticker format '%s'", ticker)})
+		return
+	}
+
+	// Claim the ticker before spending any credits; a failed scrape still uses the claim
+	remaining, err := h.scraperService.ClaimRescrape(ctx, ticker)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to claim rescrape: %v", err)})
+		return
+	}
+
+	if remaining > 0 {
+		retryAfter := int((remaining + time.Second - 1) / time.Second)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       fmt.Sprintf("Ticker %s was rescraped recently; try again later", ticker),
+			"retry_after": retryAfter,
+		})
+		return
+	}
+
+	company, err := h.scraperService.ScrapeAndStore(ctx, ticker, scraper.OxyLabsUsageRescrape)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": fmt.Sprintf("Failed to rescrape %s: %v", ticker, err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"company": company})
+}
+
+// maxBatchTickers caps the number of tickers accepted by GetCompaniesBatch
+const maxBatchTickers = 500
+
+// BatchCompaniesRequest represents a POST body for batch company lookup
+type BatchCompaniesRequest struct {
+	Tickers []string `json:"tickers" binding:"required"`
+}
+
+// GetCompaniesBatch returns multiple companies by ticker in one response.
+// Tickers come from ?tickers=A,B,C on GET or a JSON body on POST.
+func (h *UploadHandler) GetCompaniesBatch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	var rawTickers []string
+	if c.Request.Method == http.MethodPost {
+		var req BatchCompaniesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+		rawTickers = req.Tickers
+	} else {
+		rawTickers = strings.Split(c.Query("tickers"), ",")
+	}
+
+	var tickers []string
+	tickerSet := make(map[string]bool)
+	for _, raw := range rawTickers {
+		ticker := strings.TrimSpace(strings.ToUpper(raw))
+		if ticker == "" {
+			continue
+		}
+		if !h.isValidTicker(ticker) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ticker format '%s'", ticker)})
+			return
+		}
+		if !tickerSet[ticker] {
+			tickerSet[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+
+	if len(tickers) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one ticker is required"})
+		return
+	}
+
+	if len(tickers) > maxBatchTickers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many tickers. Maximum %d allowed per request", maxBatchTickers)})
+		return
+	}
+
+	companies, notFound, err := h.scraperService.GetCompaniesByTickers(ctx, tickers)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch companies: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"companies": companies,
+		"not_found": notFound,
+		"requested": len(tickers),
+		"found":     len(companies),
+	})
+}
+
 // GetSystemHealth returns overall system health status
 func (h *UploadHandler) GetSystemHealth(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
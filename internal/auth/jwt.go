@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -26,13 +27,19 @@ type Claims struct {
 
 // JWTService handles JWT token operations
 type JWTService struct {
-	secret []byte
+	keys *KeySet
 }
 
-// NewJWTService creates a new JWT service
+// NewJWTService creates a new JWT service with a single signing key
 func NewJWTService(secret string) *JWTService {
+	return NewJWTServiceWithKeys(NewKeySet("", secret, nil))
+}
+
+// NewJWTServiceWithKeys creates a JWT service that signs with the key set's
+// current key and also accepts tokens signed with its previous keys
+func NewJWTServiceWithKeys(keys *KeySet) *JWTService {
 	return &JWTService{
-		secret: []byte(secret),
+		keys: keys,
 	}
 }
 
@@ -46,7 +53,7 @@ func (j *JWTService) GenerateToken(claims Claims) (string, time.Time, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secret)
+	tokenString, err := j.keys.sign(token)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -63,7 +70,7 @@ func (j *JWTService) GenerateRefreshToken(claims Claims) (string, time.Time, err
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secret)
+	tokenString, err := j.keys.sign(token)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -72,22 +79,39 @@ func (j *JWTService) GenerateRefreshToken(claims Claims) (string, time.Time, err
 
 // ValidateToken validates a JWT token and returns claims
 func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return j.secret, nil
-	})
+	unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
 
+	keys, err := j.keys.verificationKeys(unverified)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	for _, key := range keys {
+		token, parseErr := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key, nil
+		})
+		if parseErr != nil {
+			// Only a signature mismatch means another key might match
+			if !errors.Is(parseErr, jwt.ErrTokenSignatureInvalid) {
+				return nil, fmt.Errorf("failed to parse token: %w", parseErr)
+			}
+			err = parseErr
+			continue
+		}
+
+		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+			return claims, nil
+		}
+		err = fmt.Errorf("invalid token")
 	}
 
-	return nil, fmt.Errorf("invalid token")
+	return nil, fmt.Errorf("failed to parse token: %w", err)
 }
 
 // ValidateRefreshToken validates a refresh token and returns claims
@@ -98,7 +122,12 @@ func (j *JWTService) ValidateRefreshToken(tokenString string) (*Claims, error) {
 
 // GenerateJWT is a convenience function that creates a JWT service and generates a token
 func GenerateJWT(userID uuid.UUID, role, secret string) (string, time.Time, error) {
-	service := NewJWTService(secret)
+	return GenerateJWTWithKeys(userID, role, NewKeySet("", secret, nil))
+}
+
+// GenerateJWTWithKeys generates a token signed with the key set's current key
+func GenerateJWTWithKeys(userID uuid.UUID, role string, keys *KeySet) (string, time.Time, error) {
+	service := NewJWTServiceWithKeys(keys)
 	claims := Claims{
 		UserID: userID,
 		Email:  "",
@@ -109,7 +138,13 @@ func GenerateJWT(userID uuid.UUID, role, secret string) (string, time.Time, erro
 
 // JWTMiddleware creates a middleware that validates JWT tokens from cookies
 func JWTMiddleware(secret string) gin.HandlerFunc {
-	service := NewJWTService(secret)
+	return JWTMiddlewareWithKeys(NewKeySet("", secret, nil))
+}
+
+// JWTMiddlewareWithKeys validates JWT tokens against a key set, so tokens signed
+// with a rotated-out key remain valid until they expire
+func JWTMiddlewareWithKeys(keys *KeySet) gin.HandlerFunc {
+	service := NewJWTServiceWithKeys(keys)
 	return func(c *gin.Context) {
 		// Try to get token from cookie first
 		tokenString, err := c.Cookie("auth_token")
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySet holds the current JWT signing key and prior keys that are still
+// accepted for verification, looked up by the token's "kid" header
+type KeySet struct {
+	currentID string
+	current   []byte
+	previous  map[string][]byte
+}
+
+// NewKeySet creates a key set. currentID may be empty, in which case issued
+// tokens carry no kid header.
+func NewKeySet(currentID, currentSecret string, previous map[string]string) *KeySet {
+	ks := &KeySet{
+		currentID: currentID,
+		current:   []byte(currentSecret),
+		previous:  make(map[string][]byte, len(previous)),
+	}
+	for id, secret := range previous {
+		ks.previous[id] = []byte(secret)
+	}
+	return ks
+}
+
+// KeySetFromConfig builds the key set from JWT_SECRET, JWT_KEY_ID and JWT_PREVIOUS_KEYS
+func KeySetFromConfig(cfg *config.Config) *KeySet {
+	return NewKeySet(cfg.JWTKeyID, cfg.JWTSecret, cfg.GetJWTPreviousKeys())
+}
+
+// sign signs a token with the current key, stamping its key ID
+func (ks *KeySet) sign(token *jwt.Token) (string, error) {
+	if ks.currentID != "" {
+		token.Header["kid"] = ks.currentID
+	}
+	return token.SignedString(ks.current)
+}
+
+// verificationKeys returns the keys a token may be verified with. Tokens with
+// a kid use exactly that key; tokens issued before key IDs were configured
+// carry none and are tried against every key.
+func (ks *KeySet) verificationKeys(token *jwt.Token) ([][]byte, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		keys := [][]byte{ks.current}
+		for _, key := range ks.previous {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+
+	if kid == ks.currentID {
+		return [][]byte{ks.current}, nil
+	}
+	if key, ok := ks.previous[kid]; ok {
+		return [][]byte{key}, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	userID := uuid.New()
+	oldService := NewJWTServiceWithKeys(NewKeySet("k1", "old-secret", nil))
+	oldToken, _, err := oldService.GenerateToken(Claims{UserID: userID, Role: "user"})
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	rotated := NewJWTServiceWithKeys(NewKeySet("k2", "new-secret", map[string]string{"k1": "old-secret"}))
+
+	claims, err := rotated.ValidateToken(oldToken)
+	if err != nil {
+		t.Fatalf("Expected token signed with previous key to validate, got %v", err)
+	}
+	if claims.UserID != userID {
+		t.Errorf("Expected user %s, got %s", userID, claims.UserID)
+	}
+
+	newToken, _, err := rotated.GenerateToken(Claims{UserID: userID, Role: "user"})
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified failed: %v", err)
+	}
+	if kid := parsed.Header["kid"]; kid != "k2" {
+		t.Errorf("Expected kid k2, got %v", kid)
+	}
+
+	retired := NewJWTServiceWithKeys(NewKeySet("k2", "new-secret", nil))
+	if _, err := retired.ValidateToken(oldToken); err == nil {
+		t.Error("Expected token signed with a retired key to be rejected")
+	}
+}
+
+func TestJWTService_TokensWithoutKeyID(t *testing.T) {
+	legacyToken, _, err := NewJWTService("old-secret").GenerateToken(Claims{UserID: uuid.New()})
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	rotated := NewJWTServiceWithKeys(NewKeySet("k2", "new-secret", map[string]string{"legacy": "old-secret"}))
+	if _, err := rotated.ValidateToken(legacyToken); err != nil {
+		t.Errorf("Expected token issued before key IDs to validate against previous keys, got %v", err)
+	}
+
+	if _, err := NewJWTService("other-secret").ValidateToken(legacyToken); err == nil {
+		t.Error("Expected token signed with an unknown secret to be rejected")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
 // DB wraps sql.DB to provide additional functionality
@@ -17,18 +18,56 @@ type DB struct {
 	*sql.DB
 }
 
-// New creates a new database connection with optimized connection pooling
-func New(databaseURL string) (*DB, error) {
+// PoolConfig holds connection pool settings
+type PoolConfig struct {
+	MaxOpenConns    int           // Maximum number of open connections
+	MaxIdleConns    int           // Maximum number of idle connections
+	ConnMaxLifetime time.Duration // Maximum lifetime of a connection
+	ConnMaxIdleTime time.Duration // Maximum idle time before closing
+}
+
+// DefaultPoolConfig returns pool settings suitable for production use
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 1 * time.Minute,
+	}
+}
+
+// PoolConfigFromConfig builds pool settings from application config, falling back to defaults
+func PoolConfigFromConfig(cfg *config.Config) PoolConfig {
+	pool := DefaultPoolConfig()
+	if cfg.DBMaxOpenConns > 0 {
+		pool.MaxOpenConns = cfg.DBMaxOpenConns
+	}
+	if cfg.DBMaxIdleConns > 0 {
+		pool.MaxIdleConns = cfg.DBMaxIdleConns
+	}
+	if cfg.DBConnMaxLifetimeMinutes > 0 {
+		pool.ConnMaxLifetime = time.Duration(cfg.DBConnMaxLifetimeMinutes) * time.Minute
+	}
+	return pool
+}
+
+// New creates a new database connection with the given connection pool settings
+func New(databaseURL string, pool PoolConfig) (*DB, error) {
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Configure connection pool for production use
-	db.SetMaxOpenConns(25)                 // Maximum number of open connections
-	db.SetMaxIdleConns(5)                  // Maximum number of idle connections
-	db.SetConnMaxLifetime(5 * time.Minute) // Maximum lifetime of a connection
-	db.SetConnMaxIdleTime(1 * time.Minute) // Maximum idle time before closing
+	// Idle connections beyond the open limit would never be used
+	if pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+		pool.MaxIdleConns = pool.MaxOpenConns
+	}
+
+	// Configure connection pool
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
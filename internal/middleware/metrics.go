@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLatencyBuckets are the histogram upper bounds in seconds
+var defaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics records per-route request counts, latencies and status codes
+// and exposes them in the Prometheus text exposition format
+type Metrics struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[requestKey]int64
+	latencies map[routeKey]*latencyHistogram
+	inFlight  int64
+	gauges    map[string]gauge
+}
+
+// gauge is an application-level value read each time metrics are rendered
+type gauge struct {
+	help  string
+	value func() float64
+}
+
+// routeKey identifies a route by method and registered path template
+type routeKey struct {
+	method string
+	route  string
+}
+
+// requestKey identifies a route and response status code
+type requestKey struct {
+	routeKey
+	status int
+}
+
+// latencyHistogram holds latency observations for a route
+type latencyHistogram struct {
+	counts []int64 // one per bucket, non-cumulative
+	sum    float64
+	count  int64
+}
+
+// NewMetrics creates a new metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets:   defaultLatencyBuckets,
+		requests:  make(map[requestKey]int64),
+		latencies: make(map[routeKey]*latencyHistogram),
+		gauges:    make(map[string]gauge),
+	}
+}
+
+// RegisterGauge exposes an application value as a Prometheus gauge. value is
+// called on every scrape, so it should return a cached figure rather than query.
+func (m *Metrics) RegisterGauge(name, help string, value func() float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gauges[name] = gauge{help: help, value: value}
+}
+
+// Middleware records metrics for every request. Routes are labelled by their
+// registered path template (e.g. /api/v1/jobs/:id) to keep label cardinality bounded.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		m.mu.Lock()
+		m.inFlight++
+		m.mu.Unlock()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.observe(routeKey{method: c.Request.Method, route: route}, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// observe records a single completed request
+func (m *Metrics) observe(key routeKey, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+	m.requests[requestKey{routeKey: key, status: status}]++
+
+	hist, exists := m.latencies[key]
+	if !exists {
+		hist = &latencyHistogram{counts: make([]int64, len(m.buckets))}
+		m.latencies[key] = hist
+	}
+
+	seconds := duration.Seconds()
+	for i, upperBound := range m.buckets {
+		if seconds <= upperBound {
+			hist.counts[i]++
+			break
+		}
+	}
+	hist.sum += seconds
+	hist.count++
+}
+
+// Handler serves the collected metrics in Prometheus text format
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(m.render()))
+	}
+}
+
+// render produces the Prometheus text exposition of all metrics
+func (m *Metrics) render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP http_requests_total Total HTTP requests by method, route and status code.\n")
+	b.WriteString("# TYPE http_requests_total counter\n")
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		if requestKeys[i].routeKey != requestKeys[j].routeKey {
+			return lessRouteKey(requestKeys[i].routeKey, requestKeys[j].routeKey)
+		}
+		return requestKeys[i].status < requestKeys[j].status
+	})
+	for _, key := range requestKeys {
+		fmt.Fprintf(&b, "http_requests_total{method=%q,route=%q,status=\"%d\"} %d\n",
+			key.method, key.route, key.status, m.requests[key])
+	}
+
+	b.WriteString("# HELP http_request_duration_seconds HTTP request latency by method and route.\n")
+	b.WriteString("# TYPE http_request_duration_seconds histogram\n")
+	routeKeys := make([]routeKey, 0, len(m.latencies))
+	for key := range m.latencies {
+		routeKeys = append(routeKeys, key)
+	}
+	sort.Slice(routeKeys, func(i, j int) bool { return lessRouteKey(routeKeys[i], routeKeys[j]) })
+	for _, key := range routeKeys {
+		hist := m.latencies[key]
+		var cumulative int64
+		for i, upperBound := range m.buckets {
+			cumulative += hist.counts[i]
+			fmt.Fprintf(&b, "http_request_duration_seconds_bucket{method=%q,route=%q,le=%q} %d\n",
+				key.method, key.route, strconv.FormatFloat(upperBound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "http_request_duration_seconds_bucket{method=%q,route=%q,le=\"+Inf\"} %d\n", key.method, key.route, hist.count)
+		fmt.Fprintf(&b, "http_request_duration_seconds_sum{method=%q,route=%q} %g\n", key.method, key.route, hist.sum)
+		fmt.Fprintf(&b, "http_request_duration_seconds_count{method=%q,route=%q} %d\n", key.method, key.route, hist.count)
+	}
+
+	b.WriteString("# HELP http_requests_in_flight HTTP requests currently being served.\n")
+	b.WriteString("# TYPE http_requests_in_flight gauge\n")
+	fmt.Fprintf(&b, "http_requests_in_flight %d\n", m.inFlight)
+
+	gaugeNames := make([]string, 0, len(m.gauges))
+	for name := range m.gauges {
+		gaugeNames = append(gaugeNames, name)
+	}
+	sort.Strings(gaugeNames)
+	for _, name := range gaugeNames {
+		g := m.gauges[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, g.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&b, "%s %g\n", name, g.value())
+	}
+
+	return b.String()
+}
+
+// lessRouteKey orders route keys by route then method for stable output
+func lessRouteKey(a, b routeKey) bool {
+	if a.route != b.route {
+		return a.route < b.route
+	}
+	return a.method < b.method
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	metrics := NewMetrics()
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/jobs/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/jobs/1", "/jobs/2", "/jobs/missing", "/nope"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+
+	body := w.Body.String()
+	// Requests are grouped by route template, not raw path
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/jobs/:id",status="200"} 2`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/jobs/:id",status="404"} 1`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/jobs/:id"} 3`)
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/jobs/:id",le="+Inf"} 3`)
+	assert.NotContains(t, body, "/jobs/1")
+}
+
+func TestMetricsGauge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	metrics := NewMetrics()
+	remaining := 42
+	metrics.RegisterGauge("scores_requirements_unknown", "Scores awaiting recomputation.", func() float64 {
+		return float64(remaining)
+	})
+
+	router := gin.New()
+	router.GET("/metrics", metrics.Handler())
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE scores_requirements_unknown gauge")
+	assert.Contains(t, body, "scores_requirements_unknown 42\n")
+
+	// Gauges are read at render time
+	remaining = 7
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), "scores_requirements_unknown 7\n")
+}
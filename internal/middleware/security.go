@@ -102,8 +102,50 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+// ContentTypePolicy controls which request content types POST/PUT requests may use.
+// Routes are keyed by method and registered path, e.g. "POST /api/v1/upload/csv".
+type ContentTypePolicy struct {
+	Default []string
+	Routes  map[string][]string
+}
+
+// DefaultContentTypePolicy requires JSON everywhere except the CSV upload, which is multipart
+func DefaultContentTypePolicy() ContentTypePolicy {
+	return ContentTypePolicy{
+		Default: []string{"application/json"},
+		Routes: map[string][]string{
+			"POST /api/v1/upload/csv": {"multipart/form-data"},
+		},
+	}
+}
+
+// ContentTypePolicyFromConfig builds the default policy, replacing the default
+// content types with ALLOWED_CONTENT_TYPES when it is set
+func ContentTypePolicyFromConfig(cfg *config.Config) ContentTypePolicy {
+	policy := DefaultContentTypePolicy()
+	if types := cfg.GetAllowedContentTypes(); len(types) > 0 {
+		policy.Default = types
+	}
+	return policy
+}
+
+// allowedFor returns the content types accepted by a route, falling back to the default
+func (p ContentTypePolicy) allowedFor(method, route string) []string {
+	if types, ok := p.Routes[method+" "+route]; ok {
+		return types
+	}
+	return p.Default
+}
+
 // InputValidationMiddleware provides basic input validation and sanitization
+// using the default content type policy
 func InputValidationMiddleware() gin.HandlerFunc {
+	return InputValidationMiddlewareWithPolicy(DefaultContentTypePolicy())
+}
+
+// InputValidationMiddlewareWithPolicy provides basic input validation, checking
+// POST/PUT content types against the policy for the matched route
+func InputValidationMiddlewareWithPolicy(policy ContentTypePolicy) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Set maximum request size (10MB)
 		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 10*1024*1024)
@@ -119,16 +161,12 @@ func InputValidationMiddleware() gin.HandlerFunc {
 				return
 			}
 			
-			// Only allow specific content types
-			allowedTypes := []string{
-				"application/json",
-				"multipart/form-data",
-				"application/x-www-form-urlencoded",
-			}
+			// Only allow the content types configured for this route
+			allowedTypes := policy.allowedFor(c.Request.Method, c.FullPath())
 			
 			isValidType := false
 			for _, allowedType := range allowedTypes {
-				if strings.HasPrefix(contentType, allowedType) {
+				if strings.HasPrefix(strings.ToLower(contentType), allowedType) {
 					isValidType = true
 					break
 				}
@@ -245,6 +245,45 @@ func TestInputValidationMiddleware(t *testing.T) {
 	}
 }
 
+func TestInputValidationMiddlewareRouteContentTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(InputValidationMiddleware())
+	router.POST("/api/v1/upload/csv", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "uploaded"})
+	})
+	router.POST("/api/v1/scoring/models", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "created"})
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		contentType    string
+		expectedStatus int
+	}{
+		{"Upload accepts multipart", "/api/v1/upload/csv", "multipart/form-data; boundary=abc", http.StatusOK},
+		{"Upload rejects JSON", "/api/v1/upload/csv", "application/json", http.StatusUnsupportedMediaType},
+		{"Other POST accepts JSON", "/api/v1/scoring/models", "application/json; charset=utf-8", http.StatusOK},
+		{"Other POST rejects multipart", "/api/v1/scoring/models", "multipart/form-data; boundary=abc", http.StatusUnsupportedMediaType},
+		{"Other POST rejects form encoding", "/api/v1/scoring/models", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, nil)
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestRateLimitingMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -15,6 +15,8 @@ type Company struct {
 	Ticker           string    `json:"ticker" db:"ticker"`
 	CompanyName      string    `json:"company_name" db:"company_name"`
 	MarketTier       string    `json:"market_tier" db:"market_tier"`
+	// MarketTierRaw is the tier text as scraped; MarketTier holds its canonical value
+	MarketTierRaw    string    `json:"market_tier_raw" db:"market_tier_raw"`
 	QuoteStatus      string    `json:"quote_status" db:"quote_status"`
 	TradingVolume    int64     `json:"trading_volume" db:"trading_volume"`
 	Website          string    `json:"website" db:"website"`
@@ -27,10 +29,36 @@ type Company struct {
 	Last10QDate      *time.Time `json:"last_10q_date" db:"last_10q_date"`
 	LastFilingDate   *time.Time `json:"last_filing_date" db:"last_filing_date"`
 	ProfileVerified  bool      `json:"profile_verified" db:"profile_verified"`
+	CUSIP            string    `json:"cusip" db:"cusip"`
+	CIK              string    `json:"cik" db:"cik"`
+	ReportingStatus  string    `json:"reporting_status" db:"reporting_status"`
+	// FilingDatesUncertain is set while missing 10-K/10-Q dates haven't been confirmed by repeated scrapes
+	FilingDatesUncertain bool  `json:"filing_dates_uncertain" db:"filing_dates_uncertain"`
+	// PaidPromotion is set when disclosures mention a paid stock promotion
+	PaidPromotion    bool      `json:"paid_promotion" db:"paid_promotion"`
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Reporting standards a company can follow, as shown on its OTC disclosure page
+const (
+	ReportingStatusSEC           = "sec_reporting"
+	ReportingStatusAlternative   = "alternative_reporting"
+	ReportingStatusBank          = "bank_reporting"
+	ReportingStatusInternational = "international_reporting"
+	ReportingStatusNonReporting  = "non_reporting"
+)
+
+// Canonical market tiers stored in market_tier
+const (
+	MarketTierExpertMarket = "ExpertMarket"
+	MarketTierPinkLimited  = "PinkLimited"
+	MarketTierPinkCurrent  = "PinkCurrent"
+	MarketTierOTCQB        = "OTCQB"
+	MarketTierOTCQX        = "OTCQX"
+	MarketTierGreyMarket   = "GreyMarket"
+)
+
 // Officers represents company officers as JSON
 type Officers []Officer
 
@@ -98,6 +126,8 @@ type ScrapedData struct {
 	Disclosure  map[string]interface{} `json:"disclosure"`
 	ScrapedAt   time.Time             `json:"scraped_at"`
 	Errors      []string              `json:"errors,omitempty"`
+	// OxyLabsRequests is how many page requests were sent to OxyLabs for this ticker
+	OxyLabsRequests int               `json:"oxylabs_requests"`
 }
 
 // ScrapeJob represents a scraping job
@@ -111,6 +141,10 @@ type ScrapeJob struct {
 	StartedAt         time.Time `json:"started_at" db:"started_at"`
 	CompletedAt       *time.Time `json:"completed_at" db:"completed_at"`
 	ErrorMessage      string    `json:"error_message" db:"error_message"`
+	// Priority decides which job's tickers get scraper capacity first
+	Priority          string    `json:"priority" db:"priority"`
+	// OxyLabsRequests is how many OxyLabs page requests (credits) the job has consumed
+	OxyLabsRequests   int       `json:"oxylabs_requests" db:"oxylabs_requests"`
 }
 
 // ScrapeJobStatus represents scrape job status values
@@ -121,4 +155,13 @@ const (
 	ScrapeJobRunning   ScrapeJobStatus = "running"
 	ScrapeJobCompleted ScrapeJobStatus = "completed"
 	ScrapeJobFailed    ScrapeJobStatus = "failed"
+)
+
+// ScrapeJobPriority represents scrape job priority values
+type ScrapeJobPriority string
+
+const (
+	ScrapeJobPriorityHigh   ScrapeJobPriority = "high"
+	ScrapeJobPriorityNormal ScrapeJobPriority = "normal"
+	ScrapeJobPriorityLow    ScrapeJobPriority = "low"
 )
\ No newline at end of file
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// canonicalMarketTiers lists the values market_tier may hold
+var canonicalMarketTiers = []string{
+	MarketTierExpertMarket,
+	MarketTierPinkLimited,
+	MarketTierPinkCurrent,
+	MarketTierOTCQB,
+	MarketTierOTCQX,
+	MarketTierGreyMarket,
+}
+
+// defaultMarketTierAliases are checked in order, so more specific tiers come first.
+// Pink No Information securities moved to the Expert Market under Rule 15c2-11.
+// Keep in sync with migration 013 and the normalize_market_tier SQL function (migration 020).
+var defaultMarketTierAliases = []struct {
+	pattern *regexp.Regexp
+	tier    string
+}{
+	{regexp.MustCompile(`(?i)expert\s*market`), MarketTierExpertMarket},
+	{regexp.MustCompile(`(?i)gr[ae]y\s*market`), MarketTierGreyMarket},
+	{regexp.MustCompile(`(?i)pink\s*no\s*information`), MarketTierExpertMarket},
+	{regexp.MustCompile(`(?i)pink\s*limited`), MarketTierPinkLimited},
+	{regexp.MustCompile(`(?i)otcqx`), MarketTierOTCQX},
+	{regexp.MustCompile(`(?i)otcqb|venture\s*market`), MarketTierOTCQB},
+	{regexp.MustCompile(`(?i)pink`), MarketTierPinkCurrent},
+}
+
+// CanonicalMarketTier returns the canonical spelling of tier if it already is one
+func CanonicalMarketTier(tier string) string {
+	for _, canonical := range canonicalMarketTiers {
+		if strings.EqualFold(strings.TrimSpace(tier), canonical) {
+			return canonical
+		}
+	}
+	return ""
+}
+
+// NormalizeMarketTier maps raw tier text (e.g. "OTC Pink", "Pink Current Information")
+// to its canonical tier using the default aliases, or returns "" when the text isn't recognized
+func NormalizeMarketTier(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	if canonical := CanonicalMarketTier(raw); canonical != "" {
+		return canonical
+	}
+
+	for _, alias := range defaultMarketTierAliases {
+		if alias.pattern.MatchString(raw) {
+			return alias.tier
+		}
+	}
+	return ""
+}
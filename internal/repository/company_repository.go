@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -26,6 +27,7 @@ func (r *companyRepository) GetByID(id uuid.UUID) (*models.Company, error) {
 		SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
 			   website, description, officers, address, transfer_agent, auditor,
 			   last_10k_date, last_10q_date, last_filing_date, profile_verified,
+			   reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 			   created_at, updated_at
 		FROM companies WHERE id = $1
 	`
@@ -37,6 +39,7 @@ func (r *companyRepository) GetByID(id uuid.UUID) (*models.Company, error) {
 		&company.Description, &company.Officers, &company.Address,
 		&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 		&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+		&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 		&company.CreatedAt, &company.UpdatedAt,
 	)
 	
@@ -56,6 +59,7 @@ func (r *companyRepository) GetByTicker(ticker string) (*models.Company, error)
 		SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
 			   website, description, officers, address, transfer_agent, auditor,
 			   last_10k_date, last_10q_date, last_filing_date, profile_verified,
+			   reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 			   created_at, updated_at
 		FROM companies WHERE ticker = $1
 	`
@@ -67,6 +71,7 @@ func (r *companyRepository) GetByTicker(ticker string) (*models.Company, error)
 		&company.Description, &company.Officers, &company.Address,
 		&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 		&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+		&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 		&company.CreatedAt, &company.UpdatedAt,
 	)
 	
@@ -95,9 +100,10 @@ func (r *companyRepository) Create(company *models.Company) error {
 			id, ticker, company_name, market_tier, quote_status, trading_volume,
 			website, description, officers, address, transfer_agent, auditor,
 			last_10k_date, last_10q_date, last_filing_date, profile_verified,
+			reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 			created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24
 		)
 	`
 	
@@ -107,7 +113,8 @@ func (r *companyRepository) Create(company *models.Company) error {
 		company.Description, company.Officers, company.Address,
 		company.TransferAgent, company.Auditor, company.Last10KDate,
 		company.Last10QDate, company.LastFilingDate, company.ProfileVerified,
-		company.CreatedAt, company.UpdatedAt,
+		company.ReportingStatus, company.FilingDatesUncertain, company.CUSIP,
+		company.CIK, company.PaidPromotion, company.MarketTierRaw, company.CreatedAt, company.UpdatedAt,
 	)
 	
 	if err != nil {
@@ -127,7 +134,8 @@ func (r *companyRepository) Update(company *models.Company) error {
 			website = $6, description = $7, officers = $8, address = $9,
 			transfer_agent = $10, auditor = $11, last_10k_date = $12,
 			last_10q_date = $13, last_filing_date = $14, profile_verified = $15,
-			updated_at = $16
+			reporting_status = $16, filing_dates_uncertain = $17, cusip = $18,
+			cik = $19, paid_promotion = $20, market_tier_raw = $21, updated_at = $22
 		WHERE id = $1
 	`
 	
@@ -136,7 +144,8 @@ func (r *companyRepository) Update(company *models.Company) error {
 		company.TradingVolume, company.Website, company.Description,
 		company.Officers, company.Address, company.TransferAgent, company.Auditor,
 		company.Last10KDate, company.Last10QDate, company.LastFilingDate,
-		company.ProfileVerified, company.UpdatedAt,
+		company.ProfileVerified, company.ReportingStatus, company.FilingDatesUncertain,
+		company.CUSIP, company.CIK, company.PaidPromotion, company.MarketTierRaw, company.UpdatedAt,
 	)
 	
 	if err != nil {
@@ -182,6 +191,7 @@ func (r *companyRepository) GetAll(filters CompanyFilters) ([]models.Company, er
 		SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
 			   website, description, officers, address, transfer_agent, auditor,
 			   last_10k_date, last_10q_date, last_filing_date, profile_verified,
+			   reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 			   created_at, updated_at
 		FROM companies
 	`
@@ -281,6 +291,7 @@ func (r *companyRepository) GetAll(filters CompanyFilters) ([]models.Company, er
 			&company.Description, &company.Officers, &company.Address,
 			&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 			&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+			&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 			&company.CreatedAt, &company.UpdatedAt,
 		)
 		if err != nil {
@@ -298,6 +309,7 @@ func (r *companyRepository) GetUnscored(criteria UnscoredCriteria) ([]models.Com
 		SELECT c.id, c.ticker, c.company_name, c.market_tier, c.quote_status, c.trading_volume,
 			   c.website, c.description, c.officers, c.address, c.transfer_agent, c.auditor,
 			   c.last_10k_date, c.last_10q_date, c.last_filing_date, c.profile_verified,
+			   c.reporting_status, c.filing_dates_uncertain, c.cusip, c.cik, c.paid_promotion, c.market_tier_raw,
 			   c.created_at, c.updated_at
 		FROM companies c
 	`
@@ -349,6 +361,7 @@ func (r *companyRepository) GetUnscored(criteria UnscoredCriteria) ([]models.Com
 			&company.Description, &company.Officers, &company.Address,
 			&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 			&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+			&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 			&company.CreatedAt, &company.UpdatedAt,
 		)
 		if err != nil {
@@ -380,4 +393,78 @@ func (r *companyRepository) GetAllIDs() ([]uuid.UUID, error) {
 	}
 	
 	return ids, nil
-}
\ No newline at end of file
+}
+
+// TierChangedAtExpr evaluates to when the companies row aliased c entered its current
+// market tier: the first snapshot after the latest one recorded with a different tier,
+// or NULL if the tier never changed. Snapshot tiers go through normalize_market_tier so
+// raw text from before normalization ("OTC Pink") matches canonical values.
+const TierChangedAtExpr = `(
+	SELECT MIN(th.scraped_at) FROM company_history th
+	WHERE th.company_id = c.id
+	  AND th.scraped_at > (
+		SELECT MAX(tp.scraped_at) FROM company_history tp
+		WHERE tp.company_id = c.id
+		  AND normalize_market_tier(tp.snapshot_data->'company_data'->>'market_tier') != ''
+		  AND normalize_market_tier(tp.snapshot_data->'company_data'->>'market_tier')
+		      != c.market_tier))`
+
+// GetTierChangedAt returns when the company moved into its current market tier,
+// or nil if its history shows no tier change
+func (r *companyRepository) GetTierChangedAt(id uuid.UUID) (*time.Time, error) {
+	var changedAt sql.NullTime
+	err := r.db.QueryRow(`SELECT `+TierChangedAtExpr+` FROM companies c WHERE c.id = $1`, id).Scan(&changedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tier change time: %w", err)
+	}
+
+	if !changedAt.Valid {
+		return nil, nil
+	}
+	return &changedAt.Time, nil
+}
+
+// GetLastScrapedAt returns when the company was last scraped, or nil if it has no history
+func (r *companyRepository) GetLastScrapedAt(id uuid.UUID) (*time.Time, error) {
+	var lastScraped sql.NullTime
+	err := r.db.QueryRow(`SELECT MAX(COALESCE(last_seen_at, scraped_at)) FROM company_history WHERE company_id = $1`, id).Scan(&lastScraped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last scrape time: %w", err)
+	}
+
+	if !lastScraped.Valid {
+		return nil, nil
+	}
+	return &lastScraped.Time, nil
+}
+
+// GetSnapshot returns the company as it was stored by one historical scrape,
+// along with when that scrape ran
+func (r *companyRepository) GetSnapshot(companyID, snapshotID uuid.UUID) (*models.Company, time.Time, error) {
+	var snapshotJSON []byte
+	var scrapedAt time.Time
+	err := r.db.QueryRow(`
+		SELECT snapshot_data, scraped_at
+		FROM company_history
+		WHERE id = $1 AND company_id = $2`,
+		snapshotID, companyID,
+	).Scan(&snapshotJSON, &scrapedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, time.Time{}, fmt.Errorf("snapshot %s not found", snapshotID)
+		}
+		return nil, time.Time{}, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	var snapshot struct {
+		CompanyData *models.Company `json:"company_data"`
+	}
+	if err := json.Unmarshal(snapshotJSON, &snapshot); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snapshot.CompanyData == nil {
+		return nil, time.Time{}, fmt.Errorf("snapshot %s has no company data", snapshotID)
+	}
+
+	return snapshot.CompanyData, scrapedAt, nil
+}
@@ -21,12 +21,18 @@ type CompanyRepository interface {
 	GetAll(filters CompanyFilters) ([]models.Company, error)
 	GetUnscored(criteria UnscoredCriteria) ([]models.Company, error)
 	GetAllIDs() ([]uuid.UUID, error)
+
+	// History
+	GetLastScrapedAt(id uuid.UUID) (*time.Time, error)
+	GetTierChangedAt(id uuid.UUID) (*time.Time, error)
+	GetSnapshot(companyID, snapshotID uuid.UUID) (*models.Company, time.Time, error)
 }
 
 // ScoringRepository defines the interface for scoring data access
 type ScoringRepository interface {
 	// Scoring model operations
 	GetActiveModels() ([]scoring.ICPModel, error)
+	GetAllModels() ([]scoring.ICPModel, error)
 	GetModelByID(id string) (*scoring.ICPModel, error)
 	CreateModel(model *scoring.ICPModel, userID uuid.UUID) error
 	UpdateModel(model *scoring.ICPModel) error
@@ -35,9 +41,14 @@ type ScoringRepository interface {
 	// Score operations
 	StoreScore(score *scoring.ScoreResult) error
 	GetScoresByCompany(companyID uuid.UUID) ([]scoring.ScoreResult, error)
+	GetScoresByCompanyPage(companyID uuid.UUID, limit, offset int) ([]scoring.ScoreResult, int, error)
 	GetScoresByModel(modelID string) ([]scoring.ScoreResult, error)
 	DeleteScoresByCompany(companyID uuid.UUID) error
 	DeleteScoresByModel(modelID string) error
+
+	// Reference list and keyword set operations
+	GetReferenceLists(listType string) (map[string][]string, error)
+	UpsertReferenceList(listType, name string, items []string) error
 }
 
 // UserRepository defines the interface for user data access
@@ -52,8 +63,16 @@ type UserRepository interface {
 // TransactionManager defines the interface for database transaction management
 type TransactionManager interface {
 	WithTransaction(fn func(repos *Repositories) error) error
+	// WithAdvisoryLock runs fn while holding a Postgres advisory lock on
+	// (namespace, key), serializing callers across processes
+	WithAdvisoryLock(namespace int32, key string, fn func() error) error
 }
 
+// Advisory lock namespaces, so keys from different features never collide
+const (
+	LockNamespaceCompanyScoring int32 = 1
+)
+
 // Repositories groups all repository interfaces
 type Repositories struct {
 	Company CompanyRepository
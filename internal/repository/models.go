@@ -14,6 +14,7 @@ type Company struct {
 	Ticker           string    `json:"ticker"`
 	CompanyName      string    `json:"company_name"`
 	MarketTier       string    `json:"market_tier"`
+	MarketTierRaw    string    `json:"market_tier_raw"`
 	QuoteStatus      string    `json:"quote_status"`
 	TradingVolume    int64     `json:"trading_volume"`
 	Website          string    `json:"website"`
@@ -26,6 +27,8 @@ type Company struct {
 	Last10QDate      *time.Time `json:"last_10q_date"`
 	LastFilingDate   *time.Time `json:"last_filing_date"`
 	ProfileVerified  bool      `json:"profile_verified"`
+	ReportingStatus  string    `json:"reporting_status"`
+	PaidPromotion    bool      `json:"paid_promotion"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
@@ -56,11 +59,16 @@ type CompanyScore struct {
 	CompanyID       uuid.UUID `json:"company_id"`
 	ScoringModelID  string    `json:"scoring_model_id"`
 	Score           int       `json:"score"`
+	ScorePercent    float64   `json:"score_percent"`
 	Qualified       bool      `json:"qualified"`
 	RequirementsMet bool      `json:"requirements_met"`
 	Breakdown       string    `json:"breakdown"` // JSON string
 	ScoredAt        time.Time `json:"scored_at"`
 	ModelName       string    `json:"model_name,omitempty"`
+
+	// Diagnostics, only populated on freshly computed scores (not persisted)
+	PointsToQualify    int                         `json:"points_to_qualify,omitempty"`
+	FailedRequirements []scoring.FailedRequirement `json:"failed_requirements,omitempty"`
 }
 
 // LoginResponse represents the response from login
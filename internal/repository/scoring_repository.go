@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -63,6 +65,44 @@ func (r *scoringRepository) GetActiveModels() ([]scoring.ICPModel, error) {
 	return models, nil
 }
 
+// GetAllModels retrieves every scoring model, including inactive ones
+func (r *scoringRepository) GetAllModels() ([]scoring.ICPModel, error) {
+	query := `
+		SELECT id, name, description, rules, version, is_active, created_at, updated_at 
+		FROM scoring_models 
+		ORDER BY name
+	`
+	
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scoring models: %w", err)
+	}
+	defer rows.Close()
+	
+	var models []scoring.ICPModel
+	for rows.Next() {
+		var id, name, description string
+		var rulesJSON []byte
+		var version int
+		var isActive bool
+		var createdAt, updatedAt time.Time
+		
+		err := rows.Scan(&id, &name, &description, &rulesJSON, &version, &isActive, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scoring model: %w", err)
+		}
+		
+		model, err := r.engine.LoadICPModelFromJSON(id, name, description, version, rulesJSON, isActive, createdAt, updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ICP model %s from JSON: %w", name, err)
+		}
+		
+		models = append(models, *model)
+	}
+	
+	return models, nil
+}
+
 // GetModelByID retrieves a specific scoring model by ID
 func (r *scoringRepository) GetModelByID(id string) (*scoring.ICPModel, error) {
 	query := `
@@ -96,6 +136,7 @@ func (r *scoringRepository) CreateModel(model *scoring.ICPModel, userID uuid.UUI
 		"must_not":       model.Exclusions,
 		"scoring_rules":  model.Rules,
 		"minimum_score":  model.MinScore,
+		"category_bonus": model.CategoryBonus,
 	}
 	
 	rulesJSON, err := json.Marshal(rules)
@@ -136,6 +177,7 @@ func (r *scoringRepository) UpdateModel(model *scoring.ICPModel) error {
 		"must_not":       model.Exclusions,
 		"scoring_rules":  model.Rules,
 		"minimum_score":  model.MinScore,
+		"category_bonus": model.CategoryBonus,
 	}
 	
 	rulesJSON, err := json.Marshal(rules)
@@ -205,31 +247,95 @@ func (r *scoringRepository) StoreScore(score *scoring.ScoreResult) error {
 		return fmt.Errorf("invalid company ID format: %w", err)
 	}
 	
+	// qualified_since records when the company last became qualified: it is kept
+	// while the company stays qualified and cleared when it stops qualifying
+	var qualifiedSince *time.Time
+	if score.Qualified {
+		qualifiedSince = &score.ScoredAt
+	}
+	
 	query := `
-		INSERT INTO company_scores (company_id, scoring_model_id, score, qualified, requirements_met, score_breakdown, scored_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO company_scores (company_id, scoring_model_id, score, qualified, requirements_met, score_breakdown, scored_at, score_percent, qualified_since)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (company_id, scoring_model_id) 
 		DO UPDATE SET 
 			score = $3, 
 			qualified = $4, 
 			requirements_met = $5, 
 			score_breakdown = $6, 
-			scored_at = $7
+			scored_at = $7,
+			score_percent = $8,
+			qualified_since = CASE
+				WHEN NOT EXCLUDED.qualified THEN NULL
+				WHEN company_scores.qualified THEN company_scores.qualified_since
+				ELSE EXCLUDED.qualified_since
+			END
 	`
 	
-	_, err = r.db.Exec(query, companyID, score.ScoringModelID, score.Score, score.Qualified, score.RequirementsMet, breakdownJSON, score.ScoredAt)
+	// The score and its risk indicators are written together so neither is seen without the other
+	return r.withinTx(func(db dbExecutor) error {
+		_, err := db.Exec(query, companyID, score.ScoringModelID, score.Score, score.Qualified, score.RequirementsMet, breakdownJSON, score.ScoredAt, score.ScorePercent, qualifiedSince)
+		if err != nil {
+			return fmt.Errorf("failed to store score result: %w", err)
+		}
+		
+		return replaceRiskIndicators(db, companyID, score.ScoringModelID, score.Breakdown)
+	})
+}
+
+// replaceRiskIndicators rewrites the normalized risk indicator rows for a score
+// so lead filters can join on them instead of scanning breakdown JSON
+func replaceRiskIndicators(db dbExecutor, companyID uuid.UUID, modelID string, breakdown map[string]scoring.ScoreDetail) error {
+	_, err := db.Exec(`DELETE FROM company_risk_indicators WHERE company_id = $1 AND scoring_model_id = $2`, companyID, modelID)
+	if err != nil {
+		return fmt.Errorf("failed to clear risk indicators: %w", err)
+	}
+	
+	indicators := triggeredRiskIndicators(breakdown)
+	if len(indicators) == 0 {
+		return nil
+	}
+	
+	values := make([]string, len(indicators))
+	args := []interface{}{companyID, modelID}
+	argIndex := 3
+	for i, indicator := range indicators {
+		values[i] = fmt.Sprintf("($1, $2, $%d, $%d)", argIndex, argIndex+1)
+		args = append(args, indicator, breakdown[indicator].Points)
+		argIndex += 2
+	}
+	
+	_, err = db.Exec(`
+		INSERT INTO company_risk_indicators (company_id, scoring_model_id, indicator, points)
+		VALUES `+strings.Join(values, ", "), args...)
 	if err != nil {
-		return fmt.Errorf("failed to store score result: %w", err)
+		return fmt.Errorf("failed to store risk indicators: %w", err)
 	}
 	
 	return nil
 }
 
+// triggeredRiskIndicators returns the breakdown fields that triggered and awarded points,
+// sorted. The category bonus is a derived total, not a rule, so it is never an indicator.
+func triggeredRiskIndicators(breakdown map[string]scoring.ScoreDetail) []string {
+	var indicators []string
+	for field, detail := range breakdown {
+		if field == scoring.CategoryBonusField {
+			continue
+		}
+		if detail.Triggered && detail.Points > 0 {
+			indicators = append(indicators, field)
+		}
+	}
+	sort.Strings(indicators)
+	return indicators
+}
+
 // GetScoresByCompany retrieves all scores for a company
 func (r *scoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scoring.ScoreResult, error) {
 	query := `
-		SELECT cs.scoring_model_id, cs.score, cs.qualified, cs.requirements_met, 
-		       cs.score_breakdown, cs.scored_at, sm.name as model_name
+		SELECT cs.scoring_model_id, cs.score, cs.qualified, COALESCE(cs.requirements_met, false), 
+		       cs.score_breakdown, cs.scored_at, sm.name as model_name, cs.score_percent
 		FROM company_scores cs
 		JOIN scoring_models sm ON cs.scoring_model_id = sm.id
 		WHERE cs.company_id = $1
@@ -242,6 +348,42 @@ func (r *scoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scoring.S
 	}
 	defer rows.Close()
 	
+	return scanCompanyScores(rows, companyID)
+}
+
+// GetScoresByCompanyPage retrieves one page of a company's scores, newest first,
+// with the total number of scores the company has
+func (r *scoringRepository) GetScoresByCompanyPage(companyID uuid.UUID, limit, offset int) ([]scoring.ScoreResult, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM company_scores WHERE company_id = $1", companyID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count company scores: %w", err)
+	}
+
+	query := `
+		SELECT cs.scoring_model_id, cs.score, cs.qualified, COALESCE(cs.requirements_met, false), 
+		       cs.score_breakdown, cs.scored_at, sm.name as model_name, cs.score_percent
+		FROM company_scores cs
+		JOIN scoring_models sm ON cs.scoring_model_id = sm.id
+		WHERE cs.company_id = $1
+		ORDER BY cs.scored_at DESC, cs.scoring_model_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, companyID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query company scores: %w", err)
+	}
+	defer rows.Close()
+
+	scores, err := scanCompanyScores(rows, companyID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return scores, total, nil
+}
+
+// scanCompanyScores reads score rows selected by GetScoresByCompany and GetScoresByCompanyPage
+func scanCompanyScores(rows *sql.Rows, companyID uuid.UUID) ([]scoring.ScoreResult, error) {
 	var scores []scoring.ScoreResult
 	for rows.Next() {
 		var modelID, modelName string
@@ -249,8 +391,9 @@ func (r *scoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scoring.S
 		var qualified, requirementsMet bool
 		var breakdownJSON []byte
 		var scoredAt time.Time
+		var scorePercent sql.NullFloat64
 		
-		err := rows.Scan(&modelID, &score, &qualified, &requirementsMet, &breakdownJSON, &scoredAt, &modelName)
+		err := rows.Scan(&modelID, &score, &qualified, &requirementsMet, &breakdownJSON, &scoredAt, &modelName, &scorePercent)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan score result: %w", err)
 		}
@@ -264,6 +407,7 @@ func (r *scoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scoring.S
 			CompanyID:       companyID.String(),
 			ScoringModelID:  modelID,
 			Score:           score,
+			ScorePercent:    scorePercent.Float64,
 			Qualified:       qualified,
 			RequirementsMet: requirementsMet,
 			Breakdown:       breakdown,
@@ -273,14 +417,14 @@ func (r *scoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scoring.S
 		scores = append(scores, result)
 	}
 	
-	return scores, nil
+	return scores, rows.Err()
 }
 
 // GetScoresByModel retrieves all scores for a specific model
 func (r *scoringRepository) GetScoresByModel(modelID string) ([]scoring.ScoreResult, error) {
 	query := `
-		SELECT cs.company_id, cs.score, cs.qualified, cs.requirements_met, 
-		       cs.score_breakdown, cs.scored_at
+		SELECT cs.company_id, cs.score, cs.qualified, COALESCE(cs.requirements_met, false), 
+		       cs.score_breakdown, cs.scored_at, cs.score_percent
 		FROM company_scores cs
 		WHERE cs.scoring_model_id = $1
 		ORDER BY cs.scored_at DESC
@@ -299,8 +443,9 @@ func (r *scoringRepository) GetScoresByModel(modelID string) ([]scoring.ScoreRes
 		var qualified, requirementsMet bool
 		var breakdownJSON []byte
 		var scoredAt time.Time
+		var scorePercent sql.NullFloat64
 		
-		err := rows.Scan(&companyID, &score, &qualified, &requirementsMet, &breakdownJSON, &scoredAt)
+		err := rows.Scan(&companyID, &score, &qualified, &requirementsMet, &breakdownJSON, &scoredAt, &scorePercent)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan score result: %w", err)
 		}
@@ -314,6 +459,7 @@ func (r *scoringRepository) GetScoresByModel(modelID string) ([]scoring.ScoreRes
 			CompanyID:       companyID.String(),
 			ScoringModelID:  modelID,
 			Score:           score,
+			ScorePercent:    scorePercent.Float64,
 			Qualified:       qualified,
 			RequirementsMet: requirementsMet,
 			Breakdown:       breakdown,
@@ -326,26 +472,104 @@ func (r *scoringRepository) GetScoresByModel(modelID string) ([]scoring.ScoreRes
 	return scores, nil
 }
 
-// DeleteScoresByCompany deletes all scores for a company
+// DeleteScoresByCompany deletes all scores for a company along with their risk indicators
 func (r *scoringRepository) DeleteScoresByCompany(companyID uuid.UUID) error {
-	query := `DELETE FROM company_scores WHERE company_id = $1`
+	return r.withinTx(func(db dbExecutor) error {
+		if _, err := db.Exec(`DELETE FROM company_risk_indicators WHERE company_id = $1`, companyID); err != nil {
+			return fmt.Errorf("failed to delete company risk indicators: %w", err)
+		}
+		if _, err := db.Exec(`DELETE FROM company_scores WHERE company_id = $1`, companyID); err != nil {
+			return fmt.Errorf("failed to delete company scores: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteScoresByModel deletes all scores for a model along with their risk indicators
+func (r *scoringRepository) DeleteScoresByModel(modelID string) error {
+	return r.withinTx(func(db dbExecutor) error {
+		if _, err := db.Exec(`DELETE FROM company_risk_indicators WHERE scoring_model_id = $1`, modelID); err != nil {
+			return fmt.Errorf("failed to delete model risk indicators: %w", err)
+		}
+		if _, err := db.Exec(`DELETE FROM company_scores WHERE scoring_model_id = $1`, modelID); err != nil {
+			return fmt.Errorf("failed to delete model scores: %w", err)
+		}
+		return nil
+	})
+}
+
+// withinTx runs fn in the repository's transaction, or in a new one when the
+// repository is bound to the pool rather than created by WithTransaction
+func (r *scoringRepository) withinTx(fn func(db dbExecutor) error) error {
+	pool, ok := r.db.(*sql.DB)
+	if !ok {
+		return fn(r.db)
+	}
+
+	tx, err := pool.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed: %v, rollback failed: %w", err, rollbackErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetReferenceLists retrieves all stored lists of the given type ("reference" or "keyword")
+func (r *scoringRepository) GetReferenceLists(listType string) (map[string][]string, error) {
+	query := `SELECT name, items FROM scoring_reference_lists WHERE list_type = $1 ORDER BY name`
 	
-	_, err := r.db.Exec(query, companyID)
+	rows, err := r.db.Query(query, listType)
 	if err != nil {
-		return fmt.Errorf("failed to delete company scores: %w", err)
+		return nil, fmt.Errorf("failed to query reference lists: %w", err)
 	}
+	defer rows.Close()
 	
-	return nil
+	lists := make(map[string][]string)
+	for rows.Next() {
+		var name string
+		var itemsJSON []byte
+		
+		if err := rows.Scan(&name, &itemsJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan reference list: %w", err)
+		}
+		
+		var items []string
+		if err := json.Unmarshal(itemsJSON, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reference list %s: %w", name, err)
+		}
+		
+		lists[name] = items
+	}
+	
+	return lists, nil
 }
 
-// DeleteScoresByModel deletes all scores for a model
-func (r *scoringRepository) DeleteScoresByModel(modelID string) error {
-	query := `DELETE FROM company_scores WHERE scoring_model_id = $1`
+// UpsertReferenceList creates or replaces a stored list
+func (r *scoringRepository) UpsertReferenceList(listType, name string, items []string) error {
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reference list: %w", err)
+	}
+	
+	query := `
+		INSERT INTO scoring_reference_lists (list_type, name, items)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (list_type, name) 
+		DO UPDATE SET items = $3
+	`
 	
-	_, err := r.db.Exec(query, modelID)
+	_, err = r.db.Exec(query, listType, name, itemsJSON)
 	if err != nil {
-		return fmt.Errorf("failed to delete model scores: %w", err)
+		return fmt.Errorf("failed to store reference list: %w", err)
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
+)
+
+// newScoreTestTx opens a transaction against TEST_DATABASE_URL with temporary
+// score tables shadowing the real ones, so nothing it writes outlives the test
+func newScoreTestTx(t *testing.T) *sql.Tx {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("Skipping repository test - TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		t.Skip("Skipping repository test - no database available")
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Skip("Database ping failed - connection not available for testing")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	_, err = tx.Exec(`
+		CREATE TEMP TABLE company_scores (
+			company_id UUID NOT NULL,
+			scoring_model_id UUID NOT NULL,
+			score INTEGER NOT NULL,
+			qualified BOOLEAN NOT NULL,
+			requirements_met BOOLEAN,
+			score_breakdown JSONB NOT NULL,
+			scored_at TIMESTAMP NOT NULL,
+			score_percent NUMERIC(5,1),
+			qualified_since TIMESTAMP,
+			PRIMARY KEY (company_id, scoring_model_id)
+		) ON COMMIT DROP;
+		CREATE TEMP TABLE company_risk_indicators (
+			company_id UUID NOT NULL,
+			scoring_model_id UUID NOT NULL,
+			indicator VARCHAR(100) NOT NULL,
+			points INTEGER NOT NULL,
+			PRIMARY KEY (company_id, scoring_model_id, indicator)
+		) ON COMMIT DROP`)
+	if err != nil {
+		t.Fatalf("Failed to create temporary score tables: %v", err)
+	}
+	return tx
+}
+
+func TestStoreScore_QualifiedSince(t *testing.T) {
+	tx := newScoreTestTx(t)
+	repo := NewScoringRepository(tx)
+
+	companyID := uuid.New()
+	modelID := uuid.New().String()
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	qualifiedSince := func() *time.Time {
+		t.Helper()
+		var since sql.NullTime
+		err := tx.QueryRow(`SELECT qualified_since FROM company_scores WHERE company_id = $1 AND scoring_model_id = $2`,
+			companyID, modelID).Scan(&since)
+		if err != nil {
+			t.Fatalf("Failed to read qualified_since: %v", err)
+		}
+		if !since.Valid {
+			return nil
+		}
+		return &since.Time
+	}
+
+	store := func(qualified bool, scoredAt time.Time) {
+		t.Helper()
+		err := repo.StoreScore(&scoring.ScoreResult{
+			CompanyID:      companyID.String(),
+			ScoringModelID: modelID,
+			Qualified:      qualified,
+			Breakdown:      map[string]scoring.ScoreDetail{},
+			ScoredAt:       scoredAt,
+		})
+		if err != nil {
+			t.Fatalf("StoreScore failed: %v", err)
+		}
+	}
+
+	steps := []struct {
+		name      string
+		qualified bool
+		scoredAt  time.Time
+		expected  *time.Time
+	}{
+		{name: "first score unqualified", qualified: false, scoredAt: day(1), expected: nil},
+		{name: "becomes qualified", qualified: true, scoredAt: day(2), expected: &[]time.Time{day(2)}[0]},
+		{name: "stays qualified", qualified: true, scoredAt: day(3), expected: &[]time.Time{day(2)}[0]},
+		{name: "stops qualifying", qualified: false, scoredAt: day(4), expected: nil},
+		{name: "qualifies again", qualified: true, scoredAt: day(5), expected: &[]time.Time{day(5)}[0]},
+	}
+
+	for _, step := range steps {
+		store(step.qualified, step.scoredAt)
+		got := qualifiedSince()
+		switch {
+		case step.expected == nil && got != nil:
+			t.Errorf("%s: expected no qualified_since, got %v", step.name, *got)
+		case step.expected != nil && (got == nil || !got.Equal(*step.expected)):
+			t.Errorf("%s: expected qualified_since %v, got %v", step.name, *step.expected, got)
+		}
+	}
+}
+
+func TestStoreScore_FirstScoreQualified(t *testing.T) {
+	tx := newScoreTestTx(t)
+	repo := NewScoringRepository(tx)
+
+	companyID := uuid.New()
+	modelID := uuid.New().String()
+	scoredAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	err := repo.StoreScore(&scoring.ScoreResult{
+		CompanyID:      companyID.String(),
+		ScoringModelID: modelID,
+		Qualified:      true,
+		Breakdown:      map[string]scoring.ScoreDetail{},
+		ScoredAt:       scoredAt,
+	})
+	if err != nil {
+		t.Fatalf("StoreScore failed: %v", err)
+	}
+
+	var since sql.NullTime
+	if err := tx.QueryRow(`SELECT qualified_since FROM company_scores WHERE company_id = $1`, companyID).Scan(&since); err != nil {
+		t.Fatalf("Failed to read qualified_since: %v", err)
+	}
+	if !since.Valid || !since.Time.Equal(scoredAt) {
+		t.Errorf("Expected qualified_since %v on a first qualified score, got %v", scoredAt, since)
+	}
+}
+
+func TestDeleteScores_RemovesRiskIndicators(t *testing.T) {
+	tx := newScoreTestTx(t)
+	repo := NewScoringRepository(tx)
+
+	companyID := uuid.New()
+	modelA, modelB := uuid.New().String(), uuid.New().String()
+	breakdown := map[string]scoring.ScoreDetail{"delinquent_10k": {Triggered: true, Points: 3}}
+	for _, modelID := range []string{modelA, modelB} {
+		err := repo.StoreScore(&scoring.ScoreResult{
+			CompanyID:      companyID.String(),
+			ScoringModelID: modelID,
+			Breakdown:      breakdown,
+			ScoredAt:       time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("StoreScore failed: %v", err)
+		}
+	}
+
+	countIndicators := func() int {
+		t.Helper()
+		var count int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM company_risk_indicators WHERE company_id = $1`, companyID).Scan(&count); err != nil {
+			t.Fatalf("Failed to count risk indicators: %v", err)
+		}
+		return count
+	}
+
+	if err := repo.DeleteScoresByModel(modelA); err != nil {
+		t.Fatalf("DeleteScoresByModel failed: %v", err)
+	}
+	if got := countIndicators(); got != 1 {
+		t.Errorf("Expected only the other model's indicator to remain, got %d", got)
+	}
+
+	if err := repo.DeleteScoresByCompany(companyID); err != nil {
+		t.Fatalf("DeleteScoresByCompany failed: %v", err)
+	}
+	if got := countIndicators(); got != 0 {
+		t.Errorf("Expected no risk indicators after deleting the company's scores, got %d", got)
+	}
+}
@@ -1,10 +1,53 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 )
 
+// Advisory lock acquisition is bounded so a contended or starved lock surfaces
+// as an error instead of hanging the caller
+const (
+	advisoryLockTimeout       = 30 * time.Second
+	advisoryLockRetryInterval = 50 * time.Millisecond
+)
+
+// lockHolderLimits caps concurrent advisory lock holders per pool. Each holder pins
+// a connection while fn queries on others, so unbounded holders could take every
+// connection and leave fn waiting forever. Shared by all managers on the same pool.
+var (
+	lockHolderLimitsMu sync.Mutex
+	lockHolderLimits   = make(map[*sql.DB]chan struct{})
+)
+
+// lockHolderLimit returns the holder semaphore for db, sized to half its pool,
+// or nil when the pool is unbounded
+func lockHolderLimit(db *sql.DB) chan struct{} {
+	lockHolderLimitsMu.Lock()
+	defer lockHolderLimitsMu.Unlock()
+
+	if limit, ok := lockHolderLimits[db]; ok {
+		return limit
+	}
+
+	maxOpen := db.Stats().MaxOpenConnections
+	if maxOpen <= 0 {
+		return nil
+	}
+	holders := maxOpen / 2
+	if holders < 1 {
+		holders = 1
+	}
+	limit := make(chan struct{}, holders)
+	lockHolderLimits[db] = limit
+	return limit
+}
+
 // transactionManager implements TransactionManager
 type transactionManager struct {
 	db *sql.DB
@@ -47,6 +90,57 @@ func (tm *transactionManager) WithTransaction(fn func(repos *Repositories) error
 	return nil
 }
 
+// WithAdvisoryLock runs fn while holding a session-level advisory lock. The lock
+// pins one pool connection until fn returns, while fn's own queries use others, so
+// at most half the pool may hold locks at once. Acquisition polls pg_try_advisory_lock
+// and gives up after advisoryLockTimeout.
+func (tm *transactionManager) WithAdvisoryLock(namespace int32, key string, fn func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), advisoryLockTimeout)
+	defer cancel()
+	
+	if holders := lockHolderLimit(tm.db); holders != nil {
+		select {
+		case holders <- struct{}{}:
+			defer func() { <-holders }()
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for a connection for advisory lock %d/%s", namespace, key)
+		}
+	}
+	
+	conn, err := tm.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+	defer conn.Close()
+	
+	for {
+		var acquired bool
+		err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1, hashtext($2))`, namespace, key).Scan(&acquired)
+		if err != nil {
+			return fmt.Errorf("failed to acquire advisory lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+		
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out acquiring advisory lock %d/%s", namespace, key)
+		case <-time.After(advisoryLockRetryInterval):
+		}
+	}
+	defer func() {
+		// Release with a fresh context: fn may outlive the acquisition deadline
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1, hashtext($2))`, namespace, key); err != nil {
+			log.Printf("Failed to release advisory lock %d/%s: %v", namespace, key, err)
+			// Discard the connection rather than pool one that may still hold the lock
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+	
+	return fn()
+}
+
 // dbExecutor is an interface that both *sql.DB and *sql.Tx implement
 type dbExecutor interface {
 	Query(query string, args ...interface{}) (*sql.Rows, error)
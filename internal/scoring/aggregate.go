@@ -0,0 +1,142 @@
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AggregateCondition is the value of a count_where or majority_where rule. It is
+// applied to each element of an array field such as officers.
+type AggregateCondition struct {
+	Field    string      `json:"field"`    // Element attribute, e.g. "location"
+	Operator string      `json:"operator"` // Any standard operator; empty with majority_where means "share one value"
+	Value    interface{} `json:"value"`
+	Min      int         `json:"min"` // count_where only: minimum matching elements (default 1)
+}
+
+// evaluateCountWhere reports whether at least Min elements satisfy the condition
+func (e *ScoringEngine) evaluateCountWhere(actual, expected interface{}) (bool, interface{}) {
+	cond, err := parseAggregateCondition(expected)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	elements := toElements(actual)
+	matched := e.countMatching(elements, cond)
+
+	required := cond.Min
+	if required <= 0 {
+		required = 1
+	}
+
+	return matched >= required, fmt.Sprintf("%d of %d", matched, len(elements))
+}
+
+// evaluateMajorityWhere reports whether more than half of the elements satisfy the
+// condition, or with no operator, whether more than half share the same Field value
+func (e *ScoringEngine) evaluateMajorityWhere(actual, expected interface{}) (bool, interface{}) {
+	cond, err := parseAggregateCondition(expected)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	elements := toElements(actual)
+	if len(elements) == 0 {
+		return false, "0 of 0"
+	}
+
+	var matched int
+	if cond.Operator == "" {
+		matched = largestSharedValueCount(elements, cond.Field)
+	} else {
+		matched = e.countMatching(elements, cond)
+	}
+
+	return matched*2 > len(elements), fmt.Sprintf("%d of %d", matched, len(elements))
+}
+
+// countMatching counts elements satisfying the condition's operator on its field
+func (e *ScoringEngine) countMatching(elements []map[string]interface{}, cond AggregateCondition) int {
+	matched := 0
+	for _, element := range elements {
+		if ok, _ := e.evaluateCondition(element, cond.Field, cond.Operator, cond.Value); ok {
+			matched++
+		}
+	}
+	return matched
+}
+
+// largestSharedValueCount returns how many elements share the most common
+// non-empty value of field, compared case-insensitively
+func largestSharedValueCount(elements []map[string]interface{}, field string) int {
+	counts := make(map[string]int)
+	largest := 0
+	for _, element := range elements {
+		value, exists := element[field]
+		if !exists || value == nil {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", value)))
+		if key == "" {
+			continue
+		}
+		counts[key]++
+		if counts[key] > largest {
+			largest = counts[key]
+		}
+	}
+	return largest
+}
+
+// parseAggregateCondition decodes a rule value, which arrives as a map when
+// models are loaded from JSON
+func parseAggregateCondition(expected interface{}) (AggregateCondition, error) {
+	var cond AggregateCondition
+	switch v := expected.(type) {
+	case AggregateCondition:
+		cond = v
+	case *AggregateCondition:
+		if v != nil {
+			cond = *v
+		}
+	default:
+		raw, err := json.Marshal(expected)
+		if err != nil {
+			return cond, fmt.Errorf("invalid aggregate condition: %v", err)
+		}
+		if err := json.Unmarshal(raw, &cond); err != nil {
+			return cond, fmt.Errorf("invalid aggregate condition: %v", err)
+		}
+	}
+
+	if cond.Field == "" {
+		return cond, fmt.Errorf("aggregate condition requires a field")
+	}
+	return cond, nil
+}
+
+// toElements converts an array field (typed slice, []interface{} or JSON string)
+// into generic element maps
+func toElements(actual interface{}) []map[string]interface{} {
+	var raw []byte
+	switch v := actual.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return nil
+		}
+	}
+
+	var elements []map[string]interface{}
+	if err := json.Unmarshal(raw, &elements); err != nil {
+		return nil
+	}
+	return elements
+}
@@ -3,18 +3,28 @@ package scoring
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // ScoringEngine handles ICP-based company scoring
-type ScoringEngine struct{}
+type ScoringEngine struct {
+	mu             sync.RWMutex
+	referenceLists map[string][]string
+	keywordSets    map[string][]string
+}
 
 // NewScoringEngine creates a new scoring engine instance
 func NewScoringEngine() *ScoringEngine {
-	return &ScoringEngine{}
+	return &ScoringEngine{
+		referenceLists: DefaultReferenceLists(),
+		keywordSets:    DefaultKeywordSets(),
+	}
 }
 
 // ScoringRule represents a single scoring rule
@@ -24,21 +34,33 @@ type ScoringRule struct {
 	Value       interface{} `json:"value"`
 	Weight      int         `json:"weight"`
 	Description string      `json:"description"`
+	Category    string      `json:"category,omitempty"` // Risk category, e.g. compliance, strategic or quality
 }
 
+// CategoryBonus awards extra points when triggered rules span several distinct categories
+type CategoryBonus struct {
+	MinCategories int    `json:"min_categories"`
+	Points        int    `json:"points"`
+	Description   string `json:"description,omitempty"`
+}
+
+// CategoryBonusField is the breakdown key the category bonus is reported under
+const CategoryBonusField = "category_bonus"
+
 // ICPModel represents an Ideal Customer Profile scoring model
 type ICPModel struct {
-	ID           string        `json:"id"`
-	Name         string        `json:"name"`
-	Description  string        `json:"description"`
-	Version      int           `json:"version"`
-	Requirements []Requirement `json:"must_have"`
-	Exclusions   []Requirement `json:"must_not"`
-	Rules        []ScoringRule `json:"scoring_rules"`
-	MinScore     int           `json:"minimum_score"`
-	IsActive     bool          `json:"is_active"`
-	CreatedAt    time.Time     `json:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at"`
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	Version       int            `json:"version"`
+	Requirements  []Requirement  `json:"must_have"`
+	Exclusions    []Requirement  `json:"must_not"`
+	Rules         []ScoringRule  `json:"scoring_rules"`
+	MinScore      int            `json:"minimum_score"`
+	CategoryBonus *CategoryBonus `json:"category_bonus,omitempty"`
+	IsActive      bool           `json:"is_active"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
 // Requirement represents a mandatory requirement for an ICP
@@ -51,13 +73,24 @@ type Requirement struct {
 
 // ScoreResult represents the result of scoring a company
 type ScoreResult struct {
-	CompanyID       string                 `json:"company_id"`
-	ScoringModelID  string                 `json:"scoring_model_id"`
-	Score           int                    `json:"score"`
-	Qualified       bool                   `json:"qualified"`
-	RequirementsMet bool                   `json:"requirements_met"`
-	Breakdown       map[string]ScoreDetail `json:"breakdown"`
-	ScoredAt        time.Time              `json:"scored_at"`
+	CompanyID          string                 `json:"company_id"`
+	ScoringModelID     string                 `json:"scoring_model_id"`
+	Score              int                    `json:"score"`
+	ScorePercent       float64                `json:"score_percent"`                 // Score as 0-100 of the model's max positive points
+	Qualified          bool                   `json:"qualified"`
+	RequirementsMet    bool                   `json:"requirements_met"`
+	PointsToQualify    int                    `json:"points_to_qualify"`             // MinScore minus Score, 0 once reached
+	FailedRequirements []FailedRequirement    `json:"failed_requirements,omitempty"`
+	Breakdown          map[string]ScoreDetail `json:"breakdown"`
+	ScoredAt           time.Time              `json:"scored_at"`
+}
+
+// FailedRequirement identifies a requirement or exclusion that blocked qualification
+type FailedRequirement struct {
+	Type        string `json:"type"` // requirement or exclusion
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Value       string `json:"value"`
 }
 
 // ScoreDetail provides detailed information about a scoring component
@@ -66,8 +99,12 @@ type ScoreDetail struct {
 	Triggered   bool   `json:"triggered"`
 	Description string `json:"description"`
 	Value       string `json:"value"`
+	Uncertain   bool   `json:"uncertain,omitempty"` // Not triggered because the underlying data isn't confirmed yet
 }
 
+// UncertainValue is reported as a condition's value when it can't be decided definitively
+const UncertainValue = "uncertain"
+
 // ScoreCompany scores a company against a specific ICP model
 func (e *ScoringEngine) ScoreCompany(companyData map[string]interface{}, model ICPModel) (*ScoreResult, error) {
 	result := &ScoreResult{
@@ -84,6 +121,12 @@ func (e *ScoringEngine) ScoreCompany(companyData map[string]interface{}, model I
 		met, value := e.evaluateCondition(companyData, req.Field, req.Operator, req.Value)
 		if !met {
 			result.RequirementsMet = false
+			result.FailedRequirements = append(result.FailedRequirements, FailedRequirement{
+				Type:        "requirement",
+				Field:       req.Field,
+				Description: req.Description,
+				Value:       fmt.Sprintf("%v", value),
+			})
 			result.Breakdown[req.Field+"_requirement"] = ScoreDetail{
 				Points:      0,
 				Triggered:   false,
@@ -105,6 +148,12 @@ func (e *ScoringEngine) ScoreCompany(companyData map[string]interface{}, model I
 		met, value := e.evaluateCondition(companyData, exclusion.Field, exclusion.Operator, exclusion.Value)
 		if met {
 			result.RequirementsMet = false
+			result.FailedRequirements = append(result.FailedRequirements, FailedRequirement{
+				Type:        "exclusion",
+				Field:       exclusion.Field,
+				Description: exclusion.Description,
+				Value:       fmt.Sprintf("%v", value),
+			})
 			result.Breakdown[exclusion.Field+"_exclusion"] = ScoreDetail{
 				Points:      0,
 				Triggered:   true,
@@ -132,6 +181,7 @@ func (e *ScoringEngine) ScoreCompany(companyData map[string]interface{}, model I
 				Triggered:   triggered,
 				Description: rule.Description,
 				Value:       fmt.Sprintf("%v", value),
+				Uncertain:   value == UncertainValue,
 			}
 			
 			if triggered {
@@ -142,13 +192,172 @@ func (e *ScoringEngine) ScoreCompany(companyData map[string]interface{}, model I
 			result.Breakdown[rule.Field] = detail
 		}
 
+		if detail, ok := e.evaluateCategoryBonus(result.Breakdown, model); ok {
+			result.Score += detail.Points
+			result.Breakdown[CategoryBonusField] = detail
+		}
+
 		// Check if company qualifies based on minimum score
 		result.Qualified = result.Score >= model.MinScore
+		result.ScorePercent = normalizeScore(result.Score, model.MaxPossibleScore())
+	}
+
+	if gap := model.MinScore - result.Score; gap > 0 {
+		result.PointsToQualify = gap
 	}
 
 	return result, nil
 }
 
+// MaxPossibleScore returns the sum of all positive rule weights in the model
+func (m ICPModel) MaxPossibleScore() int {
+	total := 0
+	for _, rule := range m.Rules {
+		if rule.Weight > 0 {
+			total += rule.Weight
+		}
+	}
+	if m.CategoryBonus != nil && m.CategoryBonus.Points > 0 {
+		total += m.CategoryBonus.Points
+	}
+	return total
+}
+
+// evaluateCategoryBonus reports the model's category bonus, triggered when rules that
+// added points span at least MinCategories distinct categories. ok is false when the
+// model has no bonus configured.
+func (e *ScoringEngine) evaluateCategoryBonus(breakdown map[string]ScoreDetail, model ICPModel) (ScoreDetail, bool) {
+	bonus := model.CategoryBonus
+	if bonus == nil || bonus.MinCategories <= 0 {
+		return ScoreDetail{}, false
+	}
+
+	seen := make(map[string]bool)
+	var categories []string
+	for _, rule := range model.Rules {
+		category := strings.ToLower(strings.TrimSpace(rule.Category))
+		if category == "" || seen[category] {
+			continue
+		}
+		if detail := breakdown[rule.Field]; detail.Triggered && detail.Points > 0 {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	description := bonus.Description
+	if description == "" {
+		description = fmt.Sprintf("Risk signals across %d+ categories", bonus.MinCategories)
+	}
+
+	detail := ScoreDetail{
+		Triggered:   len(categories) >= bonus.MinCategories,
+		Description: description,
+		Value:       strings.Join(categories, ", "),
+	}
+	if detail.Triggered {
+		detail.Points = bonus.Points
+	}
+	return detail, true
+}
+
+// ParseCategoryBonus reads a category_bonus object from decoded rules JSON,
+// returning nil when it is absent or malformed
+func ParseCategoryBonus(value interface{}) *CategoryBonus {
+	bonusMap, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	bonus := &CategoryBonus{
+		MinCategories: getInt(bonusMap, "min_categories"),
+		Points:        getInt(bonusMap, "points"),
+	}
+	if _, exists := bonusMap["description"]; exists {
+		bonus.Description = getString(bonusMap, "description")
+	}
+	return bonus
+}
+
+// WithWeightOverrides returns a copy of the model with rule weights replaced by the
+// overrides, keyed by rule field. Overrides for fields the model has no rule for are an error.
+func (m ICPModel) WithWeightOverrides(overrides map[string]int) (ICPModel, error) {
+	if len(overrides) == 0 {
+		return m, nil
+	}
+
+	ruleFields := make(map[string]bool, len(m.Rules))
+	for _, rule := range m.Rules {
+		ruleFields[rule.Field] = true
+	}
+
+	var unknown []string
+	for field := range overrides {
+		if !ruleFields[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return m, fmt.Errorf("weight overrides reference fields with no scoring rule: %s", strings.Join(unknown, ", "))
+	}
+
+	rules := make([]ScoringRule, len(m.Rules))
+	for i, rule := range m.Rules {
+		if weight, ok := overrides[rule.Field]; ok {
+			rule.Weight = weight
+		}
+		rules[i] = rule
+	}
+	m.Rules = rules
+
+	return m, nil
+}
+
+// TriggeredRule is a scoring rule that fired, for compact display
+type TriggeredRule struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Points      int    `json:"points"`
+}
+
+// TriggeredRules lists the rules in a breakdown that fired and changed the score,
+// ordered by points (highest first) then field. Requirement and exclusion entries carry no points and are skipped.
+func TriggeredRules(breakdown map[string]ScoreDetail) []TriggeredRule {
+	rules := []TriggeredRule{}
+	for field, detail := range breakdown {
+		if detail.Triggered && detail.Points != 0 {
+			rules = append(rules, TriggeredRule{
+				Field:       field,
+				Description: detail.Description,
+				Points:      detail.Points,
+			})
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Points != rules[j].Points {
+			return rules[i].Points > rules[j].Points
+		}
+		return rules[i].Field < rules[j].Field
+	})
+
+	return rules
+}
+
+// normalizeScore maps a raw score onto 0-100 against the max achievable points
+func normalizeScore(score, maxScore int) float64 {
+	if maxScore <= 0 || score <= 0 {
+		return 0
+	}
+	percent := float64(score) / float64(maxScore) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	return math.Round(percent*10) / 10
+}
+
 // LoadICPModelFromJSON loads an ICP model from JSON data (from database)
 func (e *ScoringEngine) LoadICPModelFromJSON(id, name, description string, version int, rulesJSON []byte, isActive bool, createdAt, updatedAt time.Time) (*ICPModel, error) {
 	var rules map[string]interface{}
@@ -212,6 +421,9 @@ func (e *ScoringEngine) LoadICPModelFromJSON(id, name, description string, versi
 						Weight:      getInt(itemMap, "weight"),
 						Description: getString(itemMap, "description"),
 					}
+					if _, exists := itemMap["category"]; exists {
+						rule.Category = getString(itemMap, "category")
+					}
 					// Handle legacy condition field
 					if condition := getString(itemMap, "condition"); condition != "" {
 						rule.Description = condition
@@ -227,6 +439,8 @@ func (e *ScoringEngine) LoadICPModelFromJSON(id, name, description string, versi
 		model.MinScore = getInt(map[string]interface{}{"minimum_score": minScore}, "minimum_score")
 	}
 
+	model.CategoryBonus = ParseCategoryBonus(rules["category_bonus"])
+
 	return model, nil
 }
 
@@ -263,7 +477,7 @@ func (e *ScoringEngine) GetDefaultICPModels() []*ICPModel {
 			Description: "Companies in Expert Market needing services to regain eligibility",
 			Version:     1,
 			Requirements: []Requirement{
-				{Field: "market_tier", Operator: "equals", Value: "Expert Market", Description: "Must be in Expert Market tier"},
+				{Field: "market_tier", Operator: "equals", Value: "ExpertMarket", Description: "Must be in Expert Market tier"},
 				{Field: "quote_status", Operator: "contains", Value: "Ineligible", Description: "Must be ineligible for solicited quotes"},
 			},
 			Rules: []ScoringRule{
@@ -273,6 +487,7 @@ func (e *ScoringEngine) GetDefaultICPModels() []*ICPModel {
 				{Field: "pink_limited_or_expert", Weight: 1, Description: "In risky market tier"},
 				{Field: "no_recent_activity", Weight: 1, Description: "No recent activity (>12 months)"},
 				{Field: "reverse_merger_shell", Weight: 1, Description: "Reverse merger or shell company indicators"},
+				{Field: "confirmed_shell", Weight: 3, Description: "Shell company disclosure language"},
 				{Field: "asian_management", Weight: 1, Description: "Asian management team"},
 				{Field: "cannabis_or_crypto", Weight: 1, Description: "Cannabis or crypto business"},
 				{Field: "holding_company_or_spac", Weight: 1, Description: "Holding company or SPAC"},
@@ -289,7 +504,7 @@ func (e *ScoringEngine) GetDefaultICPModels() []*ICPModel {
 			Description: "Active Pink sheet companies with potential compliance gaps",
 			Version:     1,
 			Requirements: []Requirement{
-				{Field: "market_tier", Operator: "equals", Value: "OTC Pink", Description: "Must be in OTC Pink tier"},
+				{Field: "market_tier", Operator: "equals", Value: "PinkCurrent", Description: "Must be in OTC Pink tier"},
 				{Field: "trading_volume", Operator: "greater_than", Value: 0, Description: "Must have trading volume"},
 			},
 			Exclusions: []Requirement{
@@ -301,6 +516,7 @@ func (e *ScoringEngine) GetDefaultICPModels() []*ICPModel {
 				{Field: "no_verified_profile", Weight: 1, Description: "Profile not verified"},
 				{Field: "no_recent_activity", Weight: 1, Description: "No recent activity (>12 months)"},
 				{Field: "reverse_merger_shell", Weight: 1, Description: "Reverse merger or shell company indicators"},
+				{Field: "confirmed_shell", Weight: 3, Description: "Shell company disclosure language"},
 				{Field: "asian_management", Weight: 1, Description: "Asian management team"},
 				{Field: "cannabis_or_crypto", Weight: 1, Description: "Cannabis or crypto business"},
 				{Field: "holding_company_or_spac", Weight: 1, Description: "Holding company or SPAC"},
@@ -319,27 +535,45 @@ func (e *ScoringEngine) evaluateCondition(data map[string]interface{}, field, op
 	// Handle special computed fields
 	switch field {
 	case "delinquent_10k":
+		if !e.filesPeriodicReports(data) {
+			return false, data["reporting_status"]
+		}
+		if e.filingDateUncertain(data, "last_10k_date") {
+			return false, UncertainValue
+		}
 		return e.evaluateDelinquency(data, "last_10k_date", 15), data["last_10k_date"]
 	case "delinquent_10q":
+		if !e.filesPeriodicReports(data) {
+			return false, data["reporting_status"]
+		}
+		if e.filingDateUncertain(data, "last_10q_date") {
+			return false, UncertainValue
+		}
 		return e.evaluateDelinquency(data, "last_10q_date", 6), data["last_10q_date"]
 	case "no_recent_activity":
 		return e.evaluateDelinquency(data, "last_filing_date", 12), data["last_filing_date"]
 	case "pink_limited_or_expert":
 		return e.evaluateMarketTierRisk(data), data["market_tier"]
 	case "reverse_merger_shell":
-		return e.evaluateDescriptionKeywords(data, []string{"reverse merger", "shell company", "shell corporation"}), data["description"]
+		return e.evaluateDescriptionKeywords(data, e.KeywordSet(KeywordsReverseMergerShell)), data["description"]
+	case "confirmed_shell":
+		return e.evaluateShellBoilerplate(data)
 	case "asian_management":
 		return e.evaluateAsianManagement(data), e.getOfficerLocations(data)
 	case "cannabis_or_crypto":
-		return e.evaluateDescriptionKeywords(data, []string{"cannabis", "cbd", "marijuana", "blockchain", "crypto", "bitcoin"}), data["description"]
+		return e.evaluateDescriptionKeywords(data, e.KeywordSet(KeywordsCannabisOrCrypto)), data["description"]
 	case "holding_company_or_spac":
-		return e.evaluateDescriptionKeywords(data, []string{"blank check", "spac", "holding company", "special purpose"}), data["description"]
+		return e.evaluateDescriptionKeywords(data, e.KeywordSet(KeywordsHoldingCompanyOrSPAC)), data["description"]
 	case "active_transfer_agent":
 		return e.evaluateActiveTransferAgent(data), data["transfer_agent"]
 	case "domain_linked_to_company":
 		return e.evaluateDomainMatch(data), data["website"]
 	case "auditor_identified":
 		return e.evaluateAuditorPresent(data), data["auditor"]
+	case "problematic_auditor":
+		return e.evaluateProblematicAuditor(data), data["auditor"]
+	case "caveat_emptor":
+		return e.evaluateCaveatEmptor(data), data["quote_status"]
 	case "no_verified_profile":
 		// Invert profile_verified for scoring
 		if verified, ok := data["profile_verified"].(bool); ok {
@@ -349,7 +583,7 @@ func (e *ScoringEngine) evaluateCondition(data map[string]interface{}, field, op
 	}
 
 	// Standard field evaluation
-	actualValue, exists := data[field]
+	actualValue, exists := e.fieldValue(data, field)
 	if !exists {
 		return false, nil
 	}
@@ -363,6 +597,10 @@ func (e *ScoringEngine) evaluateCondition(data map[string]interface{}, field, op
 		actualStr := strings.ToLower(fmt.Sprintf("%v", actualValue))
 		expectedStr := strings.ToLower(fmt.Sprintf("%v", expectedValue))
 		return strings.Contains(actualStr, expectedStr), actualValue
+	case "not_contains":
+		actualStr := strings.ToLower(fmt.Sprintf("%v", actualValue))
+		expectedStr := strings.ToLower(fmt.Sprintf("%v", expectedValue))
+		return !strings.Contains(actualStr, expectedStr), actualValue
 	case "greater_than":
 		return e.compareNumbers(actualValue, expectedValue, ">"), actualValue
 	case "less_than":
@@ -387,11 +625,61 @@ func (e *ScoringEngine) evaluateCondition(data map[string]interface{}, field, op
 		return !e.evaluateInList(actualValue, expectedValue), actualValue
 	case "regex":
 		return e.evaluateRegex(actualValue, expectedValue), actualValue
+	case "in_reference_list":
+		return e.evaluateInReferenceList(actualValue, expectedValue), actualValue
+	case "count_where":
+		return e.evaluateCountWhere(actualValue, expectedValue)
+	case "majority_where":
+		return e.evaluateMajorityWhere(actualValue, expectedValue)
 	default:
 		return false, actualValue
 	}
 }
 
+// fieldValue looks up a field for operator-based evaluation. Derived numeric fields
+// are computed here so any comparison operator can be applied to them.
+func (e *ScoringEngine) fieldValue(data map[string]interface{}, field string) (interface{}, bool) {
+	switch field {
+	case "data_age_days":
+		return e.daysSince(data, "last_scraped_at")
+	case "days_since_tier_change":
+		return e.daysSince(data, "tier_changed_at")
+	}
+
+	value, exists := data[field]
+	return value, exists
+}
+
+// daysSince returns whole days since the timestamp stored under key, which is
+// absent for companies never scraped or whose tier has never changed
+func (e *ScoringEngine) daysSince(data map[string]interface{}, key string) (interface{}, bool) {
+	at, ok := data[key].(time.Time)
+	if !ok || at.IsZero() {
+		return nil, false
+	}
+	return int(time.Since(at).Hours() / 24), true
+}
+
+// filesPeriodicReports reports whether the company is expected to file 10-K/10-Q forms.
+// Only SEC reporting companies do; an unknown reporting status is treated as SEC reporting.
+func (e *ScoringEngine) filesPeriodicReports(data map[string]interface{}) bool {
+	status, ok := data["reporting_status"].(string)
+	if !ok || status == "" {
+		return true
+	}
+	return status == "sec_reporting"
+}
+
+// filingDateUncertain reports whether a missing filing date hasn't yet been confirmed
+// by repeated scrapes, in which case delinquency shouldn't be asserted
+func (e *ScoringEngine) filingDateUncertain(data map[string]interface{}, dateField string) bool {
+	if dateValue, exists := data[dateField]; exists && dateValue != nil {
+		return false
+	}
+	uncertain, _ := data["filing_dates_uncertain"].(bool)
+	return uncertain
+}
+
 // evaluateDelinquency checks if a date field indicates delinquency
 func (e *ScoringEngine) evaluateDelinquency(data map[string]interface{}, dateField string, monthsThreshold int) bool {
 	dateValue, exists := data[dateField]
@@ -430,9 +718,7 @@ func (e *ScoringEngine) evaluateMarketTierRisk(data map[string]interface{}) bool
 	}
 
 	tierStr := strings.ToLower(fmt.Sprintf("%v", tier))
-	riskTiers := []string{"pink limited", "expert market", "grey market", "gray market"}
-	
-	for _, riskTier := range riskTiers {
+	for _, riskTier := range e.ReferenceList(ListRiskMarketTiers) {
 		if strings.Contains(tierStr, riskTier) {
 			return true
 		}
@@ -440,6 +726,24 @@ func (e *ScoringEngine) evaluateMarketTierRisk(data map[string]interface{}) bool
 	return false
 }
 
+// evaluateCaveatEmptor checks for OTC Markets' Caveat Emptor designation in the
+// quote status, ignoring case, spacing and punctuation ("Caveat Emptor",
+// "CAVEAT-EMPTOR", "Caveat Emptor (CE)")
+func (e *ScoringEngine) evaluateCaveatEmptor(data map[string]interface{}) bool {
+	status, exists := data["quote_status"]
+	if !exists || status == nil {
+		return false
+	}
+
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(fmt.Sprintf("%v", status)) {
+		if r >= 'a' && r <= 'z' {
+			normalized.WriteRune(r)
+		}
+	}
+	return strings.Contains(normalized.String(), "caveatemptor")
+}
+
 // evaluateDescriptionKeywords checks for keywords in business description
 func (e *ScoringEngine) evaluateDescriptionKeywords(data map[string]interface{}, keywords []string) bool {
 	description, exists := data["description"]
@@ -456,6 +760,33 @@ func (e *ScoringEngine) evaluateDescriptionKeywords(data map[string]interface{},
 	return false
 }
 
+// evaluateShellBoilerplate checks the description for shell-company disclosure
+// boilerplate. Unlike reverse_merger_shell's loose keywords, these phrases only
+// appear in a shell's own filings, so a match is treated as confirmation. Quote
+// and whitespace differences from PDF/HTML extraction are ignored, and the
+// matched phrase is returned for the breakdown.
+func (e *ScoringEngine) evaluateShellBoilerplate(data map[string]interface{}) (bool, interface{}) {
+	description, exists := data["description"]
+	if !exists || description == nil {
+		return false, nil
+	}
+
+	text := normalizeDisclosureText(fmt.Sprintf("%v", description))
+	for _, phrase := range e.KeywordSet(KeywordsShellBoilerplate) {
+		if strings.Contains(text, normalizeDisclosureText(phrase)) {
+			return true, phrase
+		}
+	}
+	return false, nil
+}
+
+// normalizeDisclosureText lowercases text, straightens curly quotes and dashes,
+// and collapses runs of whitespace
+func normalizeDisclosureText(text string) string {
+	text = strings.NewReplacer("\u2019", "'", "\u2018", "'", "\u201c", "\"", "\u201d", "\"", "\u2013", "-", "\u2014", "-").Replace(text)
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
 // evaluateAsianManagement checks for Asian management indicators
 func (e *ScoringEngine) evaluateAsianManagement(data map[string]interface{}) bool {
 	// Check officers data
@@ -480,12 +811,7 @@ func (e *ScoringEngine) evaluateAsianManagement(data map[string]interface{}) boo
 // containsAsianLocations checks for Asian country/region indicators
 func (e *ScoringEngine) containsAsianLocations(text string) bool {
 	text = strings.ToLower(text)
-	indicators := []string{
-		"taiwan", "tw", "hong kong", "hk", "china", "cn", "singapore", "sg",
-		"beijing", "shanghai", "shenzhen", "taipei", "macau", "mo",
-	}
-	
-	for _, indicator := range indicators {
+	for _, indicator := range e.ReferenceList(ListAsianLocations) {
 		if strings.Contains(text, indicator) {
 			return true
 		}
@@ -515,12 +841,7 @@ func (e *ScoringEngine) evaluateActiveTransferAgent(data map[string]interface{})
 	}
 
 	// Check for reputable transfer agents
-	reputableAgents := []string{
-		"computershare", "continental", "american stock", "island stock", 
-		"vstock", "pacific stock", "securities transfer", "registrar and transfer",
-	}
-	
-	for _, reputable := range reputableAgents {
+	for _, reputable := range e.ReferenceList(ListReputableTransferAgents) {
 		if strings.Contains(agentStr, reputable) {
 			return true
 		}
@@ -572,6 +893,41 @@ func (e *ScoringEngine) evaluateDomainMatch(data map[string]interface{}) bool {
 	return false
 }
 
+// evaluateProblematicAuditor checks the auditor against the problematic-firm reference list
+func (e *ScoringEngine) evaluateProblematicAuditor(data map[string]interface{}) bool {
+	auditor, exists := data["auditor"]
+	if !exists || auditor == nil {
+		return false
+	}
+
+	auditorName := normalizeFirmName(fmt.Sprintf("%v", auditor))
+	if auditorName == "" {
+		return false
+	}
+
+	for _, firm := range e.ReferenceList(ListProblematicAuditors) {
+		firmName := normalizeFirmName(firm)
+		if firmName != "" && strings.Contains(" "+auditorName+" ", " "+firmName+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFirmName lowercases a firm name and reduces punctuation to single spaces
+// so "Smith & Co., LLP" and "smith co llp" compare equal
+func normalizeFirmName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
 // evaluateAuditorPresent checks if company has an identified auditor
 func (e *ScoringEngine) evaluateAuditorPresent(data map[string]interface{}) bool {
 	auditor, exists := data["auditor"]
@@ -614,6 +970,17 @@ func (e *ScoringEngine) evaluateInList(actual, expected interface{}) bool {
 	return false
 }
 
+// evaluateInReferenceList checks if value contains any entry of the named reference list
+func (e *ScoringEngine) evaluateInReferenceList(actual, expected interface{}) bool {
+	actualStr := strings.ToLower(fmt.Sprintf("%v", actual))
+	for _, entry := range e.ReferenceList(fmt.Sprintf("%v", expected)) {
+		if strings.Contains(actualStr, entry) {
+			return true
+		}
+	}
+	return false
+}
+
 // evaluateRegex checks if value matches regex pattern
 func (e *ScoringEngine) evaluateRegex(actual, expected interface{}) bool {
 	actualStr := fmt.Sprintf("%v", actual)
@@ -674,8 +1041,8 @@ func (e *ScoringEngine) GetDoubleBlackDiamondICP() ICPModel {
 		Requirements: []Requirement{
 			{
 				Field:       "market_tier",
-				Operator:    "contains",
-				Value:       "Expert Market",
+				Operator:    "equals",
+				Value:       "ExpertMarket",
 				Description: "Market Tier must be Expert Market (⚫⚫)",
 			},
 			{
@@ -723,6 +1090,13 @@ func (e *ScoringEngine) GetDoubleBlackDiamondICP() ICPModel {
 				Weight:      1,
 				Description: "Business description suggests reverse merger or shell company",
 			},
+			{
+				Field:       "confirmed_shell",
+				Operator:    "is_true",
+				Value:       true,
+				Weight:      3,
+				Description: "Disclosures use shell company boilerplate (e.g. Rule 12b-2 shell status)",
+			},
 			{
 				Field:       "asian_management",
 				Operator:    "is_true",
@@ -831,6 +1205,13 @@ func (e *ScoringEngine) GetPinkMarketICP() ICPModel {
 				Weight:      1,
 				Description: "Business description suggests reverse merger or shell company",
 			},
+			{
+				Field:       "confirmed_shell",
+				Operator:    "is_true",
+				Value:       true,
+				Weight:      3,
+				Description: "Disclosures use shell company boilerplate (e.g. Rule 12b-2 shell status)",
+			},
 			{
 				Field:       "asian_management",
 				Operator:    "is_true",
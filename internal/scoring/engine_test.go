@@ -13,7 +13,7 @@ func TestScoringEngine_ScoreCompany(t *testing.T) {
 	companyData := map[string]interface{}{
 		"ticker":           "ABCD",
 		"company_name":     "Test Company Inc",
-		"market_tier":      "Expert Market",
+		"market_tier":      "ExpertMarket",
 		"quote_status":     "Ineligible for solicited quotes",
 		"trading_volume":   100,
 		"website":          "https://testcompany.com",
@@ -69,6 +69,155 @@ func TestScoringEngine_ScoreCompany(t *testing.T) {
 	}
 }
 
+func TestScoringEngine_ScorePercent(t *testing.T) {
+	engine := NewScoringEngine()
+
+	model := ICPModel{
+		ID: "percent-model",
+		Rules: []ScoringRule{
+			{Field: "market_tier", Operator: "equals", Value: "Expert Market", Weight: 2},
+			{Field: "trading_volume", Operator: "less_than", Value: 1000, Weight: 2},
+			{Field: "profile_verified", Operator: "is_true", Weight: -1},
+		},
+		MinScore: 1,
+	}
+
+	if maxScore := model.MaxPossibleScore(); maxScore != 4 {
+		t.Fatalf("Expected max possible score 4, got %d", maxScore)
+	}
+
+	testCases := []struct {
+		name     string
+		data     map[string]interface{}
+		expected float64
+	}{
+		{
+			name:     "All positive rules triggered",
+			data:     map[string]interface{}{"market_tier": "Expert Market", "trading_volume": 10, "profile_verified": false},
+			expected: 100,
+		},
+		{
+			name:     "Half of positive points",
+			data:     map[string]interface{}{"market_tier": "Expert Market", "trading_volume": 5000, "profile_verified": false},
+			expected: 50,
+		},
+		{
+			name:     "Negative total floors at zero",
+			data:     map[string]interface{}{"market_tier": "OTCQX", "trading_volume": 5000, "profile_verified": true},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.ScoreCompany(tc.data, model)
+			if err != nil {
+				t.Fatalf("Failed to score company: %v", err)
+			}
+			if result.ScorePercent != tc.expected {
+				t.Errorf("Expected score percent %.1f, got %.1f", tc.expected, result.ScorePercent)
+			}
+		})
+	}
+}
+
+func TestScoringEngine_QualificationDiagnostics(t *testing.T) {
+	engine := NewScoringEngine()
+
+	model := ICPModel{
+		ID: "diagnostics-model",
+		Requirements: []Requirement{
+			{Field: "market_tier", Operator: "equals", Value: "Expert Market", Description: "Must be Expert Market"},
+		},
+		Exclusions: []Requirement{
+			{Field: "profile_verified", Operator: "is_true", Description: "Must not be verified"},
+		},
+		Rules: []ScoringRule{
+			{Field: "trading_volume", Operator: "less_than", Value: 1000, Weight: 2},
+		},
+		MinScore: 5,
+	}
+
+	// Near miss: requirements met, 3 points short
+	result, err := engine.ScoreCompany(map[string]interface{}{
+		"market_tier": "Expert Market", "trading_volume": 10, "profile_verified": false,
+	}, model)
+	if err != nil {
+		t.Fatalf("Failed to score company: %v", err)
+	}
+	if result.PointsToQualify != 3 {
+		t.Errorf("Expected 3 points to qualify, got %d", result.PointsToQualify)
+	}
+	if len(result.FailedRequirements) != 0 {
+		t.Errorf("Expected no failed requirements, got %+v", result.FailedRequirements)
+	}
+
+	// Failed requirement and violated exclusion are both listed
+	result, err = engine.ScoreCompany(map[string]interface{}{
+		"market_tier": "OTCQX", "trading_volume": 10, "profile_verified": true,
+	}, model)
+	if err != nil {
+		t.Fatalf("Failed to score company: %v", err)
+	}
+	if len(result.FailedRequirements) != 2 {
+		t.Fatalf("Expected 2 failed requirements, got %+v", result.FailedRequirements)
+	}
+	if result.FailedRequirements[0].Type != "requirement" || result.FailedRequirements[0].Field != "market_tier" {
+		t.Errorf("Unexpected first failure: %+v", result.FailedRequirements[0])
+	}
+	if result.FailedRequirements[1].Type != "exclusion" || result.FailedRequirements[1].Field != "profile_verified" {
+		t.Errorf("Unexpected second failure: %+v", result.FailedRequirements[1])
+	}
+
+	// Qualified companies have no gap
+	model.MinScore = 2
+	result, err = engine.ScoreCompany(map[string]interface{}{
+		"market_tier": "Expert Market", "trading_volume": 10, "profile_verified": false,
+	}, model)
+	if err != nil {
+		t.Fatalf("Failed to score company: %v", err)
+	}
+	if result.PointsToQualify != 0 {
+		t.Errorf("Expected 0 points to qualify once qualified, got %d", result.PointsToQualify)
+	}
+}
+
+func TestICPModel_WithWeightOverrides(t *testing.T) {
+	engine := NewScoringEngine()
+
+	model := ICPModel{
+		ID: "override-model",
+		Rules: []ScoringRule{
+			{Field: "market_tier", Operator: "equals", Value: "Expert Market", Weight: 1},
+			{Field: "trading_volume", Operator: "less_than", Value: 1000, Weight: 1},
+		},
+		MinScore: 3,
+	}
+	data := map[string]interface{}{"market_tier": "Expert Market", "trading_volume": 10}
+
+	overridden, err := model.WithWeightOverrides(map[string]int{"market_tier": 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := engine.ScoreCompany(data, overridden)
+	if err != nil {
+		t.Fatalf("Failed to score company: %v", err)
+	}
+	if result.Score != 6 || !result.Qualified {
+		t.Errorf("Expected overridden score 6 and qualified, got %d (qualified=%v)", result.Score, result.Qualified)
+	}
+
+	// The original model is untouched
+	if model.Rules[0].Weight != 1 {
+		t.Errorf("Expected original weight 1 to be preserved, got %d", model.Rules[0].Weight)
+	}
+
+	if _, err := model.WithWeightOverrides(map[string]int{"auditor_identified": 2}); err == nil {
+		t.Error("Expected error for override of a field with no rule")
+	}
+}
+
 func TestScoringEngine_LoadICPModelFromJSON(t *testing.T) {
 	engine := NewScoringEngine()
 	
@@ -181,6 +330,30 @@ func TestScoringEngine_EvaluateConditions(t *testing.T) {
 			value:    "Ineligible",
 			expected: true,
 		},
+		{
+			name:     "Not contains when substring absent",
+			data:     map[string]interface{}{"description": "Shell company seeking a merger"},
+			field:    "description",
+			operator: "not_contains",
+			value:    "product",
+			expected: true,
+		},
+		{
+			name:     "Not contains is case-insensitive",
+			data:     map[string]interface{}{"description": "Sells a PRODUCT line nationwide"},
+			field:    "description",
+			operator: "not_contains",
+			value:    "product",
+			expected: false,
+		},
+		{
+			name:     "Not contains with missing field",
+			data:     map[string]interface{}{},
+			field:    "description",
+			operator: "not_contains",
+			value:    "product",
+			expected: false,
+		},
 		{
 			name:     "Greater than true",
 			data:     map[string]interface{}{"trading_volume": 1000},
@@ -225,6 +398,91 @@ func TestScoringEngine_EvaluateConditions(t *testing.T) {
 	}
 }
 
+func TestScoringEngine_EvaluateOfficerAggregates(t *testing.T) {
+	engine := NewScoringEngine()
+
+	officers := []map[string]interface{}{
+		{"name": "A. Chen", "title": "CEO", "location": "Hong Kong"},
+		{"name": "B. Lim", "title": "CFO", "location": "Singapore"},
+		{"name": "C. Smith", "title": "Director", "location": "Miami, FL"},
+	}
+	officersJSON := `[{"name":"A","location":"Taipei, Taiwan"},{"name":"B","location":"Taipei, Taiwan"},{"name":"C","location":"Denver"}]`
+
+	testCases := []struct {
+		name     string
+		data     map[string]interface{}
+		operator string
+		value    interface{}
+		expected bool
+	}{
+		{
+			name:     "Count where meets minimum",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "count_where",
+			value:    map[string]interface{}{"field": "location", "operator": "in_reference_list", "value": ListAsianLocations, "min": 2},
+			expected: true,
+		},
+		{
+			name:     "Count where below minimum",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "count_where",
+			value:    map[string]interface{}{"field": "location", "operator": "in_reference_list", "value": ListAsianLocations, "min": 3},
+			expected: false,
+		},
+		{
+			name:     "Count where defaults to one match",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "count_where",
+			value:    map[string]interface{}{"field": "title", "operator": "equals", "value": "CFO"},
+			expected: true,
+		},
+		{
+			name:     "Majority where with operator",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "majority_where",
+			value:    map[string]interface{}{"field": "location", "operator": "in_reference_list", "value": ListAsianLocations},
+			expected: true,
+		},
+		{
+			name:     "Majority share a location from JSON string",
+			data:     map[string]interface{}{"officers": officersJSON},
+			operator: "majority_where",
+			value:    map[string]interface{}{"field": "location"},
+			expected: true,
+		},
+		{
+			name:     "No majority location",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "majority_where",
+			value:    map[string]interface{}{"field": "location"},
+			expected: false,
+		},
+		{
+			name:     "Empty officers",
+			data:     map[string]interface{}{"officers": []map[string]interface{}{}},
+			operator: "majority_where",
+			value:    map[string]interface{}{"field": "location"},
+			expected: false,
+		},
+		{
+			name:     "Missing field in condition",
+			data:     map[string]interface{}{"officers": officers},
+			operator: "count_where",
+			value:    map[string]interface{}{"operator": "equals", "value": "CEO"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, _ := engine.evaluateCondition(tc.data, "officers", tc.operator, tc.value)
+			if result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestScoringEngine_EvaluateDelinquency(t *testing.T) {
 	engine := NewScoringEngine()
 	
@@ -317,6 +575,324 @@ func TestScoringEngine_EvaluateDescriptionKeywords(t *testing.T) {
 	}
 }
 
+func TestScoringEngine_ConfirmedShell(t *testing.T) {
+	engine := NewScoringEngine()
+
+	testCases := []struct {
+		name        string
+		description interface{}
+		expected    bool
+	}{
+		{
+			name:        "Rule 12b-2 shell status",
+			description: "The Company is a shell company as defined in Rule 12b-2 of the Exchange Act.",
+			expected:    true,
+		},
+		{
+			name:        "Boilerplate split across lines with curly quotes",
+			description: "Currently we have no\n  operations. Management\u2019s plan is to identify a suitable\tbusiness combination.",
+			expected:    true,
+		},
+		{
+			name:        "Loose shell keyword alone is not confirmation",
+			description: "The company completed a reverse merger with a former shell company in 2019.",
+			expected:    false,
+		},
+		{
+			name:        "Operating business",
+			description: "We manufacture and distribute industrial adhesives.",
+			expected:    false,
+		},
+		{
+			name:        "Missing description",
+			description: nil,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := map[string]interface{}{"description": tc.description}
+			met, matched := engine.evaluateCondition(data, "confirmed_shell", "is_true", true)
+			if met != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, met)
+			}
+			if met && matched == nil {
+				t.Error("Expected the matched phrase in the breakdown")
+			}
+		})
+	}
+}
+
+func TestScoringEngine_KeywordSetOverride(t *testing.T) {
+	engine := NewScoringEngine()
+	data := map[string]interface{}{"description": "Developer of psilocybin therapies"}
+
+	if met, _ := engine.evaluateCondition(data, "cannabis_or_crypto", "", nil); met {
+		t.Error("Expected default keyword set not to match")
+	}
+
+	engine.SetKeywordSet(KeywordsCannabisOrCrypto, []string{" Psilocybin ", ""})
+
+	if met, _ := engine.evaluateCondition(data, "cannabis_or_crypto", "", nil); !met {
+		t.Error("Expected overridden keyword set to match")
+	}
+
+	if got := engine.KeywordSets()[KeywordsCannabisOrCrypto]; len(got) != 1 || got[0] != "psilocybin" {
+		t.Errorf("Expected normalized keyword set [psilocybin], got %v", got)
+	}
+
+	// Other sets keep their defaults
+	if len(engine.ReferenceList(ListReputableTransferAgents)) == 0 {
+		t.Error("Expected default transfer agent list to remain loaded")
+	}
+}
+
+func TestScoringEngine_EvaluateProblematicAuditor(t *testing.T) {
+	engine := NewScoringEngine()
+	engine.SetReferenceList(ListProblematicAuditors, []string{"Sanctioned & Partners, LLP", "Acme CPA"})
+
+	testCases := []struct {
+		name     string
+		auditor  interface{}
+		expected bool
+	}{
+		{
+			name:     "Spelled-out ampersand is a different name",
+			auditor:  "Sanctioned and Partners LLP",
+			expected: false,
+		},
+		{
+			name:     "Punctuation and case differences",
+			auditor:  "SANCTIONED & PARTNERS LLP",
+			expected: true,
+		},
+		{
+			name:     "Listed firm within longer name",
+			auditor:  "Acme CPA Group, Inc.",
+			expected: true,
+		},
+		{
+			name:     "Partial word does not match",
+			auditor:  "Acmecpa Advisors",
+			expected: false,
+		},
+		{
+			name:     "Unlisted auditor",
+			auditor:  "Reputable Audit LLP",
+			expected: false,
+		},
+		{
+			name:     "Missing auditor",
+			auditor:  nil,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := map[string]interface{}{"auditor": tc.auditor}
+			met, _ := engine.evaluateCondition(data, "problematic_auditor", "is_true", true)
+			if met != tc.expected {
+				t.Errorf("Expected %v for auditor %v, got %v", tc.expected, tc.auditor, met)
+			}
+		})
+	}
+
+	// Default list is empty, so nothing is flagged out of the box
+	if met, _ := NewScoringEngine().evaluateCondition(map[string]interface{}{"auditor": "Acme CPA"}, "problematic_auditor", "is_true", true); met {
+		t.Error("Expected no match with the default empty list")
+	}
+}
+
+func TestScoringEngine_EvaluateCaveatEmptor(t *testing.T) {
+	engine := NewScoringEngine()
+
+	testCases := []struct {
+		name        string
+		quoteStatus interface{}
+		expected    bool
+	}{
+		{
+			name:        "Canonical spelling",
+			quoteStatus: "Caveat Emptor",
+			expected:    true,
+		},
+		{
+			name:        "Case, punctuation and spacing differences",
+			quoteStatus: "CAVEAT-EMPTOR  (Buyer Beware)",
+			expected:    true,
+		},
+		{
+			name:        "Run together",
+			quoteStatus: "CaveatEmptor",
+			expected:    true,
+		},
+		{
+			name:        "Other quote status",
+			quoteStatus: "Ineligible for solicited quotes",
+			expected:    false,
+		},
+		{
+			name:        "Missing quote status",
+			quoteStatus: nil,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := map[string]interface{}{"quote_status": tc.quoteStatus}
+			met, _ := engine.evaluateCondition(data, "caveat_emptor", "is_true", true)
+			if met != tc.expected {
+				t.Errorf("Expected %v for quote status %v, got %v", tc.expected, tc.quoteStatus, met)
+			}
+		})
+	}
+}
+
+func TestScoringEngine_DataAgeDays(t *testing.T) {
+	engine := NewScoringEngine()
+
+	testCases := []struct {
+		name     string
+		data     map[string]interface{}
+		expected bool
+		age      interface{}
+	}{
+		{
+			name:     "Recently scraped",
+			data:     map[string]interface{}{"last_scraped_at": time.Now().AddDate(0, 0, -10)},
+			expected: true,
+			age:      10,
+		},
+		{
+			name:     "Stale data",
+			data:     map[string]interface{}{"last_scraped_at": time.Now().AddDate(0, 0, -45)},
+			expected: false,
+			age:      45,
+		},
+		{
+			name:     "Never scraped",
+			data:     map[string]interface{}{},
+			expected: false,
+			age:      nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			met, actual := engine.evaluateCondition(tc.data, "data_age_days", "less_than", 30)
+			if met != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, met)
+			}
+			if actual != tc.age {
+				t.Errorf("Expected age %v in breakdown, got %v", tc.age, actual)
+			}
+		})
+	}
+}
+
+func TestScoringEngine_DelinquencyByReportingStatus(t *testing.T) {
+	engine := NewScoringEngine()
+	staleFiling := time.Now().AddDate(-3, 0, 0)
+
+	testCases := []struct {
+		name            string
+		reportingStatus interface{}
+		expected        bool
+	}{
+		{
+			name:            "SEC reporting company is delinquent",
+			reportingStatus: "sec_reporting",
+			expected:        true,
+		},
+		{
+			name:            "Unknown reporting status keeps delinquency",
+			reportingStatus: nil,
+			expected:        true,
+		},
+		{
+			name:            "Alternative reporting company is skipped",
+			reportingStatus: "alternative_reporting",
+			expected:        false,
+		},
+		{
+			name:            "Non-reporting company is skipped",
+			reportingStatus: "non_reporting",
+			expected:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := map[string]interface{}{
+				"last_10k_date": staleFiling,
+				"last_10q_date": staleFiling,
+			}
+			if tc.reportingStatus != nil {
+				data["reporting_status"] = tc.reportingStatus
+			}
+
+			for _, field := range []string{"delinquent_10k", "delinquent_10q"} {
+				met, _ := engine.evaluateCondition(data, field, "is_true", true)
+				if met != tc.expected {
+					t.Errorf("Expected %s=%v for reporting status %v, got %v", field, tc.expected, tc.reportingStatus, met)
+				}
+			}
+		})
+	}
+}
+
+func TestScoringEngine_UncertainFilingDates(t *testing.T) {
+	engine := NewScoringEngine()
+	model := ICPModel{
+		ID: "test-model",
+		Rules: []ScoringRule{
+			{Field: "delinquent_10k", Weight: 1, Description: "Delinquent 10-K"},
+			{Field: "delinquent_10q", Weight: 1, Description: "Delinquent 10-Q"},
+		},
+		MinScore: 1,
+	}
+
+	// Dates missing on an unconfirmed scrape are uncertain, not delinquent
+	result, err := engine.ScoreCompany(map[string]interface{}{"filing_dates_uncertain": true}, model)
+	if err != nil {
+		t.Fatalf("ScoreCompany failed: %v", err)
+	}
+	if result.Score != 0 {
+		t.Errorf("Expected score 0 while filing dates are uncertain, got %d", result.Score)
+	}
+	if detail := result.Breakdown["delinquent_10k"]; !detail.Uncertain || detail.Triggered {
+		t.Errorf("Expected delinquent_10k to be uncertain and not triggered, got %+v", detail)
+	}
+
+	// An extracted date is evaluated normally even if the other form is still missing
+	data := map[string]interface{}{
+		"filing_dates_uncertain": true,
+		"last_10k_date":          time.Now().AddDate(-2, 0, 0),
+	}
+	result, err = engine.ScoreCompany(data, model)
+	if err != nil {
+		t.Fatalf("ScoreCompany failed: %v", err)
+	}
+	if !result.Breakdown["delinquent_10k"].Triggered {
+		t.Error("Expected stale extracted 10-K date to be delinquent")
+	}
+	if !result.Breakdown["delinquent_10q"].Uncertain {
+		t.Error("Expected missing 10-Q date to be uncertain")
+	}
+
+	// Once confirmed, missing dates count as delinquent
+	result, err = engine.ScoreCompany(map[string]interface{}{"filing_dates_uncertain": false}, model)
+	if err != nil {
+		t.Fatalf("ScoreCompany failed: %v", err)
+	}
+	if result.Score != 2 {
+		t.Errorf("Expected score 2 once missing dates are confirmed, got %d", result.Score)
+	}
+}
+
 func TestScoringEngine_EvaluateTransferAgent(t *testing.T) {
 	engine := NewScoringEngine()
 	
@@ -408,7 +984,7 @@ func TestScoringEngine_PinkMarketScoring(t *testing.T) {
 	companyData := map[string]interface{}{
 		"ticker":           "PINK",
 		"company_name":     "Pink Test Company",
-		"market_tier":      "OTC Pink",
+		"market_tier":      "PinkCurrent",
 		"quote_status":     "Current Information",
 		"trading_volume":   5000,
 		"website":          "https://pinktest.com",
@@ -438,6 +1014,147 @@ func TestScoringEngine_PinkMarketScoring(t *testing.T) {
 	}
 }
 
+func TestTriggeredRules(t *testing.T) {
+	breakdown := map[string]ScoreDetail{
+		"market_tier_requirement":  {Points: 0, Triggered: true, Description: "REQUIREMENT MET: Expert Market"},
+		"delinquent_10k":           {Points: 1, Triggered: true, Description: "No 10-K filing in last 15 months"},
+		"months_since_last_filing": {Points: 2, Triggered: true, Description: "Recent filing activity"},
+		"auditor_identified":       {Points: -1, Triggered: true, Description: "Has identified CPA firm"},
+		"delinquent_10q":           {Points: 0, Triggered: false, Description: "No 10-Q filing in last 6 months"},
+		"cannabis_or_crypto":       {Points: 1, Triggered: true, Description: "Cannabis or crypto"},
+	}
+
+	rules := TriggeredRules(breakdown)
+
+	expected := []string{"months_since_last_filing", "cannabis_or_crypto", "delinquent_10k", "auditor_identified"}
+	if len(rules) != len(expected) {
+		t.Fatalf("Expected %d triggered rules, got %d: %+v", len(expected), len(rules), rules)
+	}
+	for i, field := range expected {
+		if rules[i].Field != field {
+			t.Errorf("Rule %d: expected %s, got %s", i, field, rules[i].Field)
+		}
+	}
+}
+
+func TestScoringEngine_DaysSinceTierChange(t *testing.T) {
+	engine := NewScoringEngine()
+
+	recent := map[string]interface{}{"tier_changed_at": time.Now().AddDate(0, 0, -20)}
+	met, actual := engine.evaluateCondition(recent, "days_since_tier_change", "less_than_or_equal", 90)
+	if !met || actual != 20 {
+		t.Errorf("Expected recent tier change to match with 20 days, got %v (%v)", met, actual)
+	}
+
+	neverChanged := map[string]interface{}{}
+	met, actual = engine.evaluateCondition(neverChanged, "days_since_tier_change", "less_than_or_equal", 90)
+	if met || actual != nil {
+		t.Errorf("Expected no match without a tier change, got %v (%v)", met, actual)
+	}
+}
+
+func TestScoringEngine_CategoryBonus(t *testing.T) {
+	engine := NewScoringEngine()
+	model := ICPModel{
+		ID: "test",
+		Rules: []ScoringRule{
+			{Field: "market_tier", Operator: "equals", Value: "ExpertMarket", Weight: 2, Category: "compliance"},
+			{Field: "quote_status", Operator: "equals", Value: "Ineligible", Weight: 1, Category: "compliance"},
+			{Field: "reporting_status", Operator: "equals", Value: "non_reporting", Weight: 1, Category: "quality"},
+			{Field: "auditor", Operator: "equals", Value: "", Weight: 1, Category: "strategic"},
+		},
+		CategoryBonus: &CategoryBonus{MinCategories: 3, Points: 2},
+		MinScore:      5,
+	}
+
+	twoCategories := map[string]interface{}{
+		"market_tier":      "ExpertMarket",
+		"quote_status":     "Ineligible",
+		"reporting_status": "non_reporting",
+		"auditor":          "Known Auditor LLP",
+	}
+	result, err := engine.ScoreCompany(twoCategories, model)
+	if err != nil {
+		t.Fatalf("Scoring failed: %v", err)
+	}
+	bonus := result.Breakdown[CategoryBonusField]
+	if bonus.Triggered || bonus.Points != 0 || result.Score != 4 {
+		t.Errorf("Expected no bonus for two categories, got %+v with score %d", bonus, result.Score)
+	}
+
+	threeCategories := map[string]interface{}{
+		"market_tier":      "ExpertMarket",
+		"reporting_status": "non_reporting",
+		"auditor":          "",
+	}
+	result, err = engine.ScoreCompany(threeCategories, model)
+	if err != nil {
+		t.Fatalf("Scoring failed: %v", err)
+	}
+	bonus = result.Breakdown[CategoryBonusField]
+	if !bonus.Triggered || bonus.Points != 2 || bonus.Value != "compliance, quality, strategic" {
+		t.Errorf("Expected bonus across three categories, got %+v", bonus)
+	}
+	if result.Score != 6 || !result.Qualified {
+		t.Errorf("Expected bonus to lift score to 6 and qualify, got %d (qualified %v)", result.Score, result.Qualified)
+	}
+	if max := model.MaxPossibleScore(); max != 7 {
+		t.Errorf("Expected max possible score to include the bonus, got %d", max)
+	}
+}
+
+func TestLoadICPModelFromJSON_CategoryBonus(t *testing.T) {
+	engine := NewScoringEngine()
+	rulesJSON := []byte(`{
+		"scoring_rules": [{"field": "delinquent_10k", "weight": 1, "category": "compliance"}],
+		"category_bonus": {"min_categories": 2, "points": 3},
+		"minimum_score": 2
+	}`)
+
+	model, err := engine.LoadICPModelFromJSON("id", "name", "", 1, rulesJSON, true, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to load model: %v", err)
+	}
+	if model.Rules[0].Category != "compliance" {
+		t.Errorf("Expected rule category compliance, got %q", model.Rules[0].Category)
+	}
+	if model.CategoryBonus == nil || model.CategoryBonus.MinCategories != 2 || model.CategoryBonus.Points != 3 {
+		t.Errorf("Unexpected category bonus: %+v", model.CategoryBonus)
+	}
+}
+
+func TestICPModel_Explain(t *testing.T) {
+	model := ICPModel{
+		ID:   "test",
+		Name: "Test Model",
+		Requirements: []Requirement{
+			{Field: "market_tier", Operator: "equals", Value: "ExpertMarket"},
+			{Field: "quote_status", Operator: "equals", Value: "Ineligible"},
+		},
+		Rules: []ScoringRule{
+			{Field: "delinquent_10k", Weight: 1},
+			{Field: "reverse_merger_shell", Weight: 1},
+			{Field: "active_transfer_agent", Weight: -1},
+		},
+		MinScore: 3,
+	}
+
+	explanation := model.Explain()
+
+	expected := "Requires market tier of Expert Market and quote status of Ineligible; " +
+		"rewards delinquent 10-K filings and reverse merger or shell indicators; " +
+		"penalizes reputable transfer agents; qualifies at 3+ points."
+	if explanation.Summary != expected {
+		t.Errorf("Expected summary %q, got %q", expected, explanation.Summary)
+	}
+	if len(explanation.Rewards) != 2 || explanation.Rewards[0] != "delinquent 10-K filings (+1)" {
+		t.Errorf("Unexpected rewards: %+v", explanation.Rewards)
+	}
+	if len(explanation.Penalties) != 1 || explanation.Penalties[0] != "reputable transfer agents (-1)" {
+		t.Errorf("Unexpected penalties: %+v", explanation.Penalties)
+	}
+}
+
 // Benchmark tests
 func BenchmarkScoringEngine_ScoreCompany(b *testing.B) {
 	engine := NewScoringEngine()
@@ -446,7 +1163,7 @@ func BenchmarkScoringEngine_ScoreCompany(b *testing.B) {
 	companyData := map[string]interface{}{
 		"ticker":           "BENCH",
 		"company_name":     "Benchmark Company",
-		"market_tier":      "Expert Market",
+		"market_tier":      "ExpertMarket",
 		"quote_status":     "Ineligible",
 		"trading_volume":   100,
 		"website":          "https://bench.com",
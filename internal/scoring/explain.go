@@ -0,0 +1,190 @@
+package scoring
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ModelExplanation is a plain-English description of what a model looks for
+type ModelExplanation struct {
+	ModelID      string   `json:"model_id"`
+	ModelName    string   `json:"model_name"`
+	Summary      string   `json:"summary"`
+	Requirements []string `json:"requirements"`
+	Exclusions   []string `json:"exclusions"`
+	Rewards      []string `json:"rewards"`
+	Penalties    []string `json:"penalties"`
+	MinScore     int      `json:"minimum_score"`
+}
+
+// computedFieldPhrases describes the computed boolean fields handled by evaluateCondition
+var computedFieldPhrases = map[string]string{
+	"delinquent_10k":           "delinquent 10-K filings",
+	"delinquent_10q":           "delinquent 10-Q filings",
+	"no_recent_activity":       "no recent filing activity",
+	"pink_limited_or_expert":   "a high-risk market tier",
+	"reverse_merger_shell":     "reverse merger or shell indicators",
+	"confirmed_shell":          "shell company disclosure language",
+	"asian_management":         "Asian management",
+	"cannabis_or_crypto":       "cannabis or crypto businesses",
+	"holding_company_or_spac":  "holding company or SPAC structures",
+	"active_transfer_agent":    "reputable transfer agents",
+	"domain_linked_to_company": "a website matching the company name",
+	"auditor_identified":       "an identified auditor",
+	"problematic_auditor":      "auditors on the problematic-firm list",
+	"no_verified_profile":      "an unverified OTC profile",
+	"caveat_emptor":            "a Caveat Emptor designation",
+	"paid_promotion":           "paid stock promotion",
+}
+
+// camelCaseBoundary splits canonical values such as ExpertMarket into words
+var camelCaseBoundary = regexp.MustCompile(`([a-z])([A-Z])`)
+
+// Explain derives a readable description of the model from its requirements,
+// exclusions and weighted rules
+func (m ICPModel) Explain() ModelExplanation {
+	explanation := ModelExplanation{
+		ModelID:      m.ID,
+		ModelName:    m.Name,
+		Requirements: []string{},
+		Exclusions:   []string{},
+		Rewards:      []string{},
+		Penalties:    []string{},
+		MinScore:     m.MinScore,
+	}
+
+	for _, req := range m.Requirements {
+		explanation.Requirements = append(explanation.Requirements, describeCondition(req.Field, req.Operator, req.Value))
+	}
+	for _, excl := range m.Exclusions {
+		explanation.Exclusions = append(explanation.Exclusions, describeCondition(excl.Field, excl.Operator, excl.Value))
+	}
+
+	var rewards, penalties []string
+	for _, rule := range m.Rules {
+		phrase := describeCondition(rule.Field, rule.Operator, rule.Value)
+		switch {
+		case rule.Weight > 0:
+			rewards = append(rewards, phrase)
+			explanation.Rewards = append(explanation.Rewards, fmt.Sprintf("%s (+%d)", phrase, rule.Weight))
+		case rule.Weight < 0:
+			penalties = append(penalties, phrase)
+			explanation.Penalties = append(explanation.Penalties, fmt.Sprintf("%s (%d)", phrase, rule.Weight))
+		}
+	}
+
+	if bonus := m.CategoryBonus; bonus != nil && bonus.MinCategories > 0 && bonus.Points != 0 {
+		phrase := fmt.Sprintf("risk signals across %d+ categories", bonus.MinCategories)
+		if bonus.Points > 0 {
+			rewards = append(rewards, phrase)
+			explanation.Rewards = append(explanation.Rewards, fmt.Sprintf("%s (+%d)", phrase, bonus.Points))
+		} else {
+			penalties = append(penalties, phrase)
+			explanation.Penalties = append(explanation.Penalties, fmt.Sprintf("%s (%d)", phrase, bonus.Points))
+		}
+	}
+
+	var clauses []string
+	if len(explanation.Requirements) > 0 {
+		clauses = append(clauses, "Requires "+joinPhrases(explanation.Requirements))
+	}
+	if len(explanation.Exclusions) > 0 {
+		clauses = append(clauses, "excludes "+joinPhrases(explanation.Exclusions))
+	}
+	if len(rewards) > 0 {
+		clauses = append(clauses, "rewards "+joinPhrases(rewards))
+	}
+	if len(penalties) > 0 {
+		clauses = append(clauses, "penalizes "+joinPhrases(penalties))
+	}
+	clauses = append(clauses, fmt.Sprintf("qualifies at %d+ points", m.MinScore))
+
+	summary := strings.Join(clauses, "; ") + "."
+	explanation.Summary = strings.ToUpper(summary[:1]) + summary[1:]
+
+	return explanation
+}
+
+// describeCondition renders a single field/operator/value condition
+func describeCondition(field, operator string, value interface{}) string {
+	if phrase, ok := computedFieldPhrases[field]; ok {
+		if operator == "is_false" {
+			return "not " + phrase
+		}
+		return phrase
+	}
+
+	name := strings.ReplaceAll(field, "_", " ")
+	switch operator {
+	case "equals":
+		return fmt.Sprintf("%s of %s", name, describeValue(value))
+	case "not_equals":
+		return fmt.Sprintf("%s other than %s", name, describeValue(value))
+	case "contains":
+		return fmt.Sprintf("%s containing %q", name, describeValue(value))
+	case "not_contains":
+		return fmt.Sprintf("%s not containing %q", name, describeValue(value))
+	case "greater_than":
+		return fmt.Sprintf("%s above %s", name, describeValue(value))
+	case "less_than":
+		return fmt.Sprintf("%s below %s", name, describeValue(value))
+	case "greater_than_or_equal":
+		return fmt.Sprintf("%s of at least %s", name, describeValue(value))
+	case "less_than_or_equal":
+		return fmt.Sprintf("%s of at most %s", name, describeValue(value))
+	case "is_false":
+		return "no " + name
+	case "in":
+		return fmt.Sprintf("%s of %s", name, describeValue(value))
+	case "not_in":
+		return fmt.Sprintf("%s other than %s", name, describeValue(value))
+	case "regex":
+		return fmt.Sprintf("%s matching /%v/", name, value)
+	case "in_reference_list":
+		return fmt.Sprintf("%s on the %s list", name, strings.ReplaceAll(fmt.Sprintf("%v", value), "_", " "))
+	case "count_where", "majority_where":
+		return fmt.Sprintf("%s (%s)", name, strings.ReplaceAll(operator, "_", " "))
+	default:
+		return name
+	}
+}
+
+// describeValue renders a condition value, listing slices as "a, b or c"
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = describeValue(item)
+		}
+		return joinWith(items, "or")
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = describeValue(item)
+		}
+		return joinWith(items, "or")
+	case string:
+		return camelCaseBoundary.ReplaceAllString(v, "$1 $2")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// joinPhrases joins phrases as "a, b and c"
+func joinPhrases(phrases []string) string {
+	return joinWith(phrases, "and")
+}
+
+// joinWith joins items with commas and a final conjunction
+func joinWith(items []string, conjunction string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " " + conjunction + " " + items[len(items)-1]
+	}
+}
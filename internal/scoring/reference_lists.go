@@ -0,0 +1,122 @@
+package scoring
+
+import "strings"
+
+// Reference list names used by computed scoring fields
+const (
+	ListRiskMarketTiers         = "risk_market_tiers"
+	ListAsianLocations          = "asian_location_indicators"
+	ListReputableTransferAgents = "reputable_transfer_agents"
+	ListProblematicAuditors     = "problematic_auditors"
+)
+
+// Keyword set names used by description-based computed fields
+const (
+	KeywordsReverseMergerShell   = "reverse_merger_shell"
+	KeywordsCannabisOrCrypto     = "cannabis_or_crypto"
+	KeywordsHoldingCompanyOrSPAC = "holding_company_or_spac"
+	KeywordsShellBoilerplate     = "shell_boilerplate"
+)
+
+// DefaultReferenceLists returns the built-in reference lists
+func DefaultReferenceLists() map[string][]string {
+	return map[string][]string{
+		ListRiskMarketTiers: {
+			"pinklimited", "expertmarket", "greymarket", // canonical tiers
+			"pink limited", "expert market", "grey market", "gray market",
+		},
+		ListAsianLocations: {
+			"taiwan", "tw", "hong kong", "hk", "china", "cn", "singapore", "sg",
+			"beijing", "shanghai", "shenzhen", "taipei", "macau", "mo",
+		},
+		ListReputableTransferAgents: {
+			"computershare", "continental", "american stock", "island stock",
+			"vstock", "pacific stock", "securities transfer", "registrar and transfer",
+		},
+		// Firms with prior SEC/PCAOB sanctions; maintained per deployment via scoring config import
+		ListProblematicAuditors: {},
+	}
+}
+
+// DefaultKeywordSets returns the built-in description keyword sets
+func DefaultKeywordSets() map[string][]string {
+	return map[string][]string{
+		KeywordsReverseMergerShell:   {"reverse merger", "shell company", "shell corporation"},
+		KeywordsCannabisOrCrypto:     {"cannabis", "cbd", "marijuana", "blockchain", "crypto", "bitcoin"},
+		KeywordsHoldingCompanyOrSPAC: {"blank check", "spac", "holding company", "special purpose"},
+		// Disclosure boilerplate only shell companies use; matched as whole phrases
+		KeywordsShellBoilerplate: {
+			"we have no operations",
+			"we have no or nominal operations",
+			"no operations and nominal assets",
+			"shell company as defined in rule 12b-2",
+			"we are a shell company",
+			"identify a suitable business combination",
+			"locate and combine with an existing, privately-held company",
+			"business combination with a private entity whose business presents an opportunity",
+		},
+	}
+}
+
+// ReferenceList returns the named reference list
+func (e *ScoringEngine) ReferenceList(name string) []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.referenceLists[name]
+}
+
+// KeywordSet returns the named keyword set
+func (e *ScoringEngine) KeywordSet(name string) []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.keywordSets[name]
+}
+
+// ReferenceLists returns a copy of all reference lists currently in use
+func (e *ScoringEngine) ReferenceLists() map[string][]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return copyLists(e.referenceLists)
+}
+
+// KeywordSets returns a copy of all keyword sets currently in use
+func (e *ScoringEngine) KeywordSets() map[string][]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return copyLists(e.keywordSets)
+}
+
+// SetReferenceList replaces a reference list, normalizing entries to lowercase
+func (e *ScoringEngine) SetReferenceList(name string, items []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.referenceLists[name] = NormalizeListItems(items)
+}
+
+// SetKeywordSet replaces a keyword set, normalizing entries to lowercase
+func (e *ScoringEngine) SetKeywordSet(name string, items []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keywordSets[name] = NormalizeListItems(items)
+}
+
+// NormalizeListItems lowercases and trims list entries, dropping blanks
+func NormalizeListItems(items []string) []string {
+	normalized := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			normalized = append(normalized, item)
+		}
+	}
+	return normalized
+}
+
+// copyLists deep-copies a map of named lists
+func copyLists(lists map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(lists))
+	for name, items := range lists {
+		result[name] = append([]string(nil), items...)
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+// edgarTickersURL is SEC's published CIK-to-ticker mapping
+const edgarTickersURL = "https://www.sec.gov/files/company_tickers.json"
+
+// edgarCacheTTL controls how long the downloaded mapping is reused
+const edgarCacheTTL = 24 * time.Hour
+
+// EDGARResolver resolves SEC CIK numbers to current ticker symbols
+type EDGARResolver struct {
+	httpClient *http.Client
+	userAgent  string
+	url        string
+
+	mu          sync.Mutex
+	tickerByCIK map[string]string
+	fetchedAt   time.Time
+}
+
+// edgarTickerEntry is one row of company_tickers.json
+type edgarTickerEntry struct {
+	CIK    int64  `json:"cik_str"`
+	Ticker string `json:"ticker"`
+	Title  string `json:"title"`
+}
+
+// NewEDGARResolver creates a resolver. SEC requires a descriptive User-Agent with contact details.
+func NewEDGARResolver(cfg *config.Config) *EDGARResolver {
+	return &EDGARResolver{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  cfg.SECUserAgent,
+		url:        edgarTickersURL,
+	}
+}
+
+// NormalizeCIK validates a CIK and returns it zero-padded to 10 digits as EDGAR formats it
+func NormalizeCIK(raw string) (string, error) {
+	cik := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(raw)), "CIK")
+	if cik == "" || len(cik) > 10 {
+		return "", fmt.Errorf("invalid CIK %q", raw)
+	}
+	n, err := strconv.ParseInt(cik, 10, 64)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid CIK %q", raw)
+	}
+	return fmt.Sprintf("%010d", n), nil
+}
+
+// ResolveTickers maps normalized CIKs to tickers. CIKs without a listed ticker are returned as unresolved.
+func (r *EDGARResolver) ResolveTickers(ctx context.Context, ciks []string) (map[string]string, []string, error) {
+	mapping, err := r.mapping(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolved := make(map[string]string, len(ciks))
+	unresolved := []string{}
+	for _, cik := range ciks {
+		if ticker, ok := mapping[cik]; ok {
+			resolved[cik] = ticker
+		} else {
+			unresolved = append(unresolved, cik)
+		}
+	}
+
+	return resolved, unresolved, nil
+}
+
+// mapping returns the cached CIK-to-ticker mapping, downloading it when stale
+func (r *EDGARResolver) mapping(ctx context.Context) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tickerByCIK != nil && time.Since(r.fetchedAt) < edgarCacheTTL {
+		return r.tickerByCIK, nil
+	}
+
+	if r.userAgent == "" {
+		return nil, fmt.Errorf("SEC_USER_AGENT must be set to query EDGAR")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EDGAR request: %w", err)
+	}
+	req.Header.Set("User-Agent", r.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch EDGAR ticker mapping: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("EDGAR ticker mapping returned status %d", resp.StatusCode)
+	}
+
+	var entries map[string]edgarTickerEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode EDGAR ticker mapping: %w", err)
+	}
+
+	r.tickerByCIK = buildTickerMapping(entries)
+	r.fetchedAt = time.Now()
+	return r.tickerByCIK, nil
+}
+
+// buildTickerMapping keeps the first listed ticker for each CIK. EDGAR lists a
+// company's primary security first, so rows are walked in their numeric key order.
+func buildTickerMapping(entries map[string]edgarTickerEntry) map[string]string {
+	keys := make([]int, 0, len(entries))
+	for key := range entries {
+		if n, err := strconv.Atoi(key); err == nil {
+			keys = append(keys, n)
+		}
+	}
+	sort.Ints(keys)
+
+	mapping := make(map[string]string, len(keys))
+	for _, key := range keys {
+		entry := entries[strconv.Itoa(key)]
+		cik := fmt.Sprintf("%010d", entry.CIK)
+		if _, exists := mapping[cik]; !exists && entry.Ticker != "" {
+			mapping[cik] = strings.ToUpper(entry.Ticker)
+		}
+	}
+	return mapping
+}
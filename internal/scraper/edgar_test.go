@@ -0,0 +1,44 @@
+package scraper
+
+import "testing"
+
+func TestNormalizeCIK(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		expected string
+		valid    bool
+	}{
+		{raw: "320193", expected: "0000320193", valid: true},
+		{raw: " CIK0000320193 ", expected: "0000320193", valid: true},
+		{raw: "12345678901", valid: false},
+		{raw: "ABC", valid: false},
+		{raw: "0", valid: false},
+	}
+
+	for _, tc := range testCases {
+		cik, err := NormalizeCIK(tc.raw)
+		if tc.valid && (err != nil || cik != tc.expected) {
+			t.Errorf("NormalizeCIK(%q) = %q, %v; expected %q", tc.raw, cik, err, tc.expected)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("NormalizeCIK(%q) expected error, got %q", tc.raw, cik)
+		}
+	}
+}
+
+func TestBuildTickerMapping(t *testing.T) {
+	entries := map[string]edgarTickerEntry{
+		"0":  {CIK: 320193, Ticker: "AAPL"},
+		"10": {CIK: 1067983, Ticker: "BRK-A"},
+		"2":  {CIK: 1067983, Ticker: "BRK-B"},
+	}
+
+	mapping := buildTickerMapping(entries)
+	if mapping["0000320193"] != "AAPL" {
+		t.Errorf("Expected AAPL, got %q", mapping["0000320193"])
+	}
+	// The lowest-numbered row wins for a CIK with several tickers
+	if mapping["0001067983"] != "BRK-B" {
+		t.Errorf("Expected BRK-B, got %q", mapping["0001067983"])
+	}
+}
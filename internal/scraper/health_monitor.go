@@ -42,6 +42,7 @@ type HealthStatus struct {
 	RecentFailures         []FailureRecord   `json:"recent_failures"`
 	HealthIssues           []string          `json:"health_issues"`
 	RecommendedActions     []string          `json:"recommended_actions"`
+	RateLimit              *RateLimitInfo    `json:"rate_limit,omitempty"`
 }
 
 // NewHealthMonitor creates a new health monitor
@@ -158,6 +159,25 @@ func (h *HealthMonitor) GetHealthStatus() HealthStatus {
 	return status
 }
 
+// applyRateLimit adds the latest OxyLabs rate limit state to a health status.
+// Low credits are reported as an issue ahead of time; exhaustion marks the scraper unhealthy.
+func applyRateLimit(status *HealthStatus, info RateLimitInfo, now time.Time) {
+	status.RateLimit = &info
+
+	if info.IsExhausted(now) {
+		status.IsHealthy = false
+		status.HealthIssues = append(status.HealthIssues,
+			"OxyLabs rate limit exhausted ("+info.String()+")")
+		status.RecommendedActions = append(status.RecommendedActions,
+			"Pause scraping until the limit resets or add OxyLabs credits")
+	} else if info.IsLow() {
+		status.HealthIssues = append(status.HealthIssues,
+			"OxyLabs credits running low ("+info.String()+")")
+		status.RecommendedActions = append(status.RecommendedActions,
+			"Top up OxyLabs credits or reduce scraping volume")
+	}
+}
+
 // analyzeFailurePatterns looks for patterns in recent failures
 func (h *HealthMonitor) analyzeFailurePatterns(status *HealthStatus) {
 	if len(h.recentFailures) < 3 {
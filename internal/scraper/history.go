@@ -0,0 +1,30 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/google/uuid"
+)
+
+// snapshotHash fingerprints the scraped content of a company for history
+// deduplication. Identity and bookkeeping timestamps are left out so two scrapes
+// of an unchanged company hash the same.
+func snapshotHash(company *models.Company) (string, error) {
+	content := *company
+	content.ID = uuid.Nil
+	content.CreatedAt = time.Time{}
+	content.UpdatedAt = time.Time{}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot content: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,44 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestSnapshotHash(t *testing.T) {
+	first := &models.Company{
+		ID:          uuid.New(),
+		Ticker:      "ABCD",
+		CompanyName: "ABCD Holdings",
+		MarketTier:  models.MarketTierPinkCurrent,
+		CreatedAt:   time.Now().Add(-time.Hour),
+		UpdatedAt:   time.Now().Add(-time.Hour),
+	}
+	rescraped := *first
+	rescraped.ID = uuid.New()
+	rescraped.UpdatedAt = time.Now()
+
+	firstHash, err := snapshotHash(first)
+	if err != nil {
+		t.Fatalf("snapshotHash failed: %v", err)
+	}
+	rescrapedHash, err := snapshotHash(&rescraped)
+	if err != nil {
+		t.Fatalf("snapshotHash failed: %v", err)
+	}
+	if firstHash != rescrapedHash {
+		t.Error("Expected unchanged content to hash the same regardless of ID and timestamps")
+	}
+
+	rescraped.QuoteStatus = "Ineligible for solicited quotes"
+	changedHash, err := snapshotHash(&rescraped)
+	if err != nil {
+		t.Fatalf("snapshotHash failed: %v", err)
+	}
+	if changedHash == firstHash {
+		t.Error("Expected changed content to produce a different hash")
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -19,6 +21,12 @@ type OxyLabsClient struct {
 	username   string
 	password   string
 	endpoint   string
+
+	batchConcurrency int
+	requestTimeout   time.Duration
+
+	rateLimitMu sync.RWMutex
+	rateLimit   *RateLimitInfo
 }
 
 // OxyLabsRequest represents a request to the OxyLabs API
@@ -53,13 +61,25 @@ type OxyLabsResult struct {
 
 // NewOxyLabsClient creates a new OxyLabs client
 func NewOxyLabsClient(cfg *config.Config) *OxyLabsClient {
+	batchConcurrency := cfg.OxyLabsBatchConcurrency
+	if batchConcurrency <= 0 {
+		batchConcurrency = 5
+	}
+	requestTimeout := time.Duration(cfg.OxyLabsRequestTimeoutSeconds) * time.Second
+	if requestTimeout <= 0 {
+		requestTimeout = 180 * time.Second // Generous default for rendered pages
+	}
+
 	return &OxyLabsClient{
 		httpClient: &http.Client{
-			Timeout: 180 * time.Second, // Increased timeout for rendered pages
+			Timeout: requestTimeout,
 		},
 		username: cfg.OxyLabsUsername,
 		password: cfg.OxyLabsPassword,
 		endpoint: cfg.OxyLabsEndpoint,
+
+		batchConcurrency: batchConcurrency,
+		requestTimeout:   requestTimeout,
 	}
 }
 
@@ -99,6 +119,7 @@ func (c *OxyLabsClient) Get(ctx context.Context, url string) (*goquery.Document,
 		return nil, fmt.Errorf("failed to perform request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
@@ -138,7 +159,8 @@ func (c *OxyLabsClient) Get(ctx context.Context, url string) (*goquery.Document,
 	return doc, nil
 }
 
-// GetBatch performs multiple scraping requests concurrently through OxyLabs
+// GetBatch performs multiple scraping requests concurrently through OxyLabs.
+// If the batch request fails, URLs are fetched individually with bounded concurrency.
 func (c *OxyLabsClient) GetBatch(ctx context.Context, urls []string) (map[string]*goquery.Document, map[string]error) {
 	docs := make(map[string]*goquery.Document)
 	errors := make(map[string]error)
@@ -162,30 +184,14 @@ func (c *OxyLabsClient) GetBatch(ctx context.Context, urls []string) (map[string
 	requestBody, err := json.Marshal(requests)
 	if err != nil {
 		// If batch request fails, fall back to individual requests
-		for _, url := range urls {
-			doc, err := c.Get(ctx, url)
-			if err != nil {
-				errors[url] = err
-			} else {
-				docs[url] = doc
-			}
-		}
-		return docs, errors
+		return c.getConcurrently(ctx, urls)
 	}
 
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(requestBody))
 	if err != nil {
 		// Fall back to individual requests
-		for _, url := range urls {
-			doc, err := c.Get(ctx, url)
-			if err != nil {
-				errors[url] = err
-			} else {
-				docs[url] = doc
-			}
-		}
-		return docs, errors
+		return c.getConcurrently(ctx, urls)
 	}
 
 	// Set headers
@@ -196,46 +202,23 @@ func (c *OxyLabsClient) GetBatch(ctx context.Context, urls []string) (map[string
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// Fall back to individual requests
-		for _, url := range urls {
-			doc, err := c.Get(ctx, url)
-			if err != nil {
-				errors[url] = err
-			} else {
-				docs[url] = doc
-			}
-		}
-		return docs, errors
+		return c.getConcurrently(ctx, urls)
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		// Fall back to individual requests
-		for _, url := range urls {
-			doc, err := c.Get(ctx, url)
-			if err != nil {
-				errors[url] = err
-			} else {
-				docs[url] = doc
-			}
-		}
-		return docs, errors
+		return c.getConcurrently(ctx, urls)
 	}
 
 	// Parse batch response
 	var oxyResponse OxyLabsResponse
 	if err := json.Unmarshal(respBody, &oxyResponse); err != nil {
 		// Fall back to individual requests
-		for _, url := range urls {
-			doc, err := c.Get(ctx, url)
-			if err != nil {
-				errors[url] = err
-			} else {
-				docs[url] = doc
-			}
-		}
-		return docs, errors
+		return c.getConcurrently(ctx, urls)
 	}
 
 	// Process batch results
@@ -262,6 +245,83 @@ func (c *OxyLabsClient) GetBatch(ctx context.Context, urls []string) (map[string
 	return docs, errors
 }
 
+// getConcurrently fetches URLs individually, running at most batchConcurrency
+// requests at a time and bounding each by the per-request timeout
+func (c *OxyLabsClient) getConcurrently(ctx context.Context, urls []string) (map[string]*goquery.Document, map[string]error) {
+	docs := make(map[string]*goquery.Document)
+	errors := make(map[string]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.batchConcurrency)
+
+	for _, url := range urls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errors[url] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			reqCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+			defer cancel()
+
+			doc, err := c.Get(reqCtx, url)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errors[url] = err
+			} else {
+				docs[url] = doc
+			}
+		}(url)
+	}
+
+	wg.Wait()
+	return docs, errors
+}
+
+// recordRateLimit stores rate limit headers from a response, logging a warning
+// when remaining credits first drop low or OxyLabs asks us to back off
+func (c *OxyLabsClient) recordRateLimit(header http.Header) {
+	info, ok := parseRateLimitHeaders(header, time.Now())
+	if !ok {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	previous := c.rateLimit
+	c.rateLimit = &info
+	c.rateLimitMu.Unlock()
+
+	wasLow := previous != nil && previous.IsLow()
+	if info.IsLow() && !wasLow {
+		log.Printf("⚠️  OxyLabs rate limit running low: %s", info)
+	}
+	if info.IsExhausted(info.ObservedAt) && (previous == nil || !previous.IsExhausted(info.ObservedAt)) {
+		log.Printf("❌ OxyLabs rate limit exhausted: %s", info)
+	}
+}
+
+// RateLimit returns the most recently reported rate limit state, if any
+func (c *OxyLabsClient) RateLimit() (RateLimitInfo, bool) {
+	c.rateLimitMu.RLock()
+	defer c.rateLimitMu.RUnlock()
+
+	if c.rateLimit == nil {
+		return RateLimitInfo{}, false
+	}
+	return *c.rateLimit, true
+}
+
 // Health checks if the OxyLabs API is accessible
 func (c *OxyLabsClient) Health(ctx context.Context) error {
 	// Test with a simple request
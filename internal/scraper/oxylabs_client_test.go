@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+func TestOxyLabsClient_GetBatchFallbackRespectsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) > 0 && body[0] == '[' {
+			// Reject the batch payload to force per-URL fetching
+			fmt.Fprint(w, "not json")
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		fmt.Fprint(w, `{"results":[{"content":"<html><body>ok</body></html>","status_code":200}]}`)
+	}))
+	defer server.Close()
+
+	client := NewOxyLabsClient(&config.Config{
+		OxyLabsEndpoint:              server.URL,
+		OxyLabsBatchConcurrency:      2,
+		OxyLabsRequestTimeoutSeconds: 5,
+	})
+
+	urls := []string{"https://a", "https://b", "https://c", "https://d", "https://e"}
+	docs, errs := client.GetBatch(context.Background(), urls)
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(docs) != len(urls) {
+		t.Fatalf("expected %d documents, got %d", len(urls), len(docs))
+	}
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent requests, saw %d", peak)
+	}
+}
+
+func TestOxyLabsClient_RecordsRateLimitHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "50")
+		w.Header().Set("X-RateLimit-Reset", "120")
+		fmt.Fprint(w, `{"results":[{"content":"<html><body>ok</body></html>","status_code":200}]}`)
+	}))
+	defer server.Close()
+
+	client := NewOxyLabsClient(&config.Config{OxyLabsEndpoint: server.URL})
+	if _, ok := client.RateLimit(); ok {
+		t.Fatal("expected no rate limit state before any request")
+	}
+
+	if _, err := client.Get(context.Background(), "https://www.otcmarkets.com/stock/ABCD/overview"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	info, ok := client.RateLimit()
+	if !ok {
+		t.Fatal("expected rate limit state after request")
+	}
+	if info.Remaining == nil || *info.Remaining != 50 || info.Limit == nil || *info.Limit != 1000 {
+		t.Errorf("unexpected rate limit state: %s", info)
+	}
+	if info.ResetAt == nil || info.ResetAt.Before(time.Now()) {
+		t.Errorf("expected reset time in the future, got %v", info.ResetAt)
+	}
+	if !info.IsLow() {
+		t.Error("expected 50 of 1000 remaining to be reported as low")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 )
 
 // Parser handles parsing of OTC Markets pages
@@ -84,7 +85,10 @@ func (p *Parser) ParseOverviewPage(doc *goquery.Document) map[string]interface{}
 	// Look for market tier patterns in all text
 	tierPatterns := []string{
 		`Pink\s+Limited`,
+		`Pink\s+No\s+Information`,
+		`Pink\s+Current`,
 		`Pink\s+Market`,
+		`OTC\s+Pink`,
 		`Expert\s+Market`,
 		`OTCQX`,
 		`OTCQB`,
@@ -130,6 +134,16 @@ func (p *Parser) ParseOverviewPage(doc *goquery.Document) map[string]interface{}
 		data["website"] = "https://" + matches[1]
 	}
 
+	// Look for the CUSIP security identifier
+	if cusip := p.parseCUSIP(allText); cusip != "" {
+		data["cusip"] = cusip
+	}
+
+	// Promotion notices can appear on the overview page as well as in disclosures
+	if p.detectPaidPromotion(allText) {
+		data["paid_promotion"] = true
+	}
+
 	// Extract business description for keyword analysis
 	p.extractBusinessDescription(doc, data, allText)
 	
@@ -271,6 +285,21 @@ func (p *Parser) ParseDisclosurePage(doc *goquery.Document) map[string]interface
 		}
 	}
 
+	// CUSIP is also listed among the security details on the disclosure page
+	if cusip := p.parseCUSIP(allText); cusip != "" {
+		data["cusip"] = cusip
+	}
+
+	// Reporting standard (SEC, alternative, bank, international or non-reporting)
+	if status := p.parseReportingStatus(allText); status != "" {
+		data["reporting_status"] = status
+	}
+
+	// Paid stock promotion disclosures (a classic pump signal)
+	if p.detectPaidPromotion(allText) {
+		data["paid_promotion"] = true
+	}
+
 	// Look for latest filing dates
 	datePatterns := []string{
 		`([0-9]{1,2}[/\-][0-9]{1,2}[/\-][0-9]{2,4})`,
@@ -307,12 +336,114 @@ func (p *Parser) ParseDisclosurePage(doc *goquery.Document) map[string]interface
 
 // Helper methods
 
+// parseCUSIP extracts a CUSIP following a "CUSIP" label, accepting only
+// identifiers with a valid check digit
+func (p *Parser) parseCUSIP(text string) string {
+	re := regexp.MustCompile(`(?i)CUSIP[:#\s]*([0-9A-Z*@#]{9})\b`)
+	for _, matches := range re.FindAllStringSubmatch(text, -1) {
+		cusip := strings.ToUpper(matches[1])
+		if isValidCUSIP(cusip) {
+			return cusip
+		}
+	}
+	return ""
+}
+
+// isValidCUSIP verifies the CUSIP check digit (modulus 10 double-add-double)
+func isValidCUSIP(cusip string) bool {
+	if len(cusip) != 9 || cusip[8] < '0' || cusip[8] > '9' {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 8; i++ {
+		c := cusip[i]
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		case c == '*':
+			v = 36
+		case c == '@':
+			v = 37
+		case c == '#':
+			v = 38
+		default:
+			return false
+		}
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+
+	return int(cusip[8]-'0') == (10-sum%10)%10
+}
+
+// parseReportingStatus detects the company's reporting standard from page text.
+// Non-reporting is checked first so "Non-SEC Reporting" isn't read as SEC reporting.
+func (p *Parser) parseReportingStatus(text string) string {
+	statusPatterns := []struct {
+		pattern string
+		status  string
+	}{
+		{`(?i)non[\s-]*(?:sec[\s-]*)?reporting`, models.ReportingStatusNonReporting},
+		{`(?i)pink\s+no\s+information`, models.ReportingStatusNonReporting},
+		{`(?i)alternative\s+reporting`, models.ReportingStatusAlternative},
+		{`(?i)bank\s+reporting`, models.ReportingStatusBank},
+		{`(?i)international\s+reporting`, models.ReportingStatusInternational},
+		{`(?i)sec\s+reporting`, models.ReportingStatusSEC},
+	}
+
+	for _, sp := range statusPatterns {
+		re := regexp.MustCompile(sp.pattern)
+		if re.MatchString(text) {
+			return sp.status
+		}
+	}
+	return ""
+}
+
+// paidPromotionPatterns match language disclosing paid stock promotion
+var paidPromotionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)promotional\s+(?:campaign|activit(?:y|ies)|materials?)`),
+	regexp.MustCompile(`(?i)paid\s+(?:advertisement|advertising|promotion|promoter|stock\s+promotion)`),
+	regexp.MustCompile(`(?i)investor\s+relations\s+(?:firm|company|consultant|provider)s?\s+(?:was\s+|were\s+|has\s+been\s+|have\s+been\s+)?(?:compensated|paid)`),
+	regexp.MustCompile(`(?i)stock\s+promotion`),
+	regexp.MustCompile(`(?i)promotion\s+alert`),
+}
+
+// promotionNegationPattern catches denials such as "not aware of any promotional activity"
+var promotionNegationPattern = regexp.MustCompile(`(?i)\b(?:no|not|never|none|without)\b[^.;]*$`)
+
+// detectPaidPromotion reports whether text discloses paid promotion, ignoring
+// matches negated earlier in the same sentence
+func (p *Parser) detectPaidPromotion(text string) bool {
+	for _, re := range paidPromotionPatterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			start := loc[0] - 80
+			if start < 0 {
+				start = 0
+			}
+			if !promotionNegationPattern.MatchString(text[start:loc[0]]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // isMarketTier checks if text represents a market tier
 func (p *Parser) isMarketTier(text string) bool {
 	text = strings.ToLower(strings.TrimSpace(text))
 	marketTiers := []string{
 		"pink limited",
+		"pink no information",
+		"pink current",
 		"pink market",
+		"otc pink",
 		"expert market",
 		"otcqx",
 		"otcqb",
@@ -0,0 +1,89 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// loadFixture parses an HTML fixture from testdata
+func loadFixture(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to open fixture %s: %v", name, err)
+	}
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatalf("Failed to parse fixture %s: %v", name, err)
+	}
+	return doc
+}
+
+func TestParser_ParseCUSIP(t *testing.T) {
+	parser := NewParser()
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Labelled CUSIP",
+			text:     "Security Details CUSIP: 037833100 Shares Outstanding",
+			expected: "037833100",
+		},
+		{
+			name:     "Lowercase letters are normalized",
+			text:     "cusip # 38259p508",
+			expected: "38259P508",
+		},
+		{
+			name:     "Invalid check digit is rejected",
+			text:     "CUSIP: 037833101",
+			expected: "",
+		},
+		{
+			name:     "Unlabelled identifier is ignored",
+			text:     "Reference 037833100",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parser.parseCUSIP(tc.text); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_PaidPromotion(t *testing.T) {
+	parser := NewParser()
+
+	t.Run("Disclosed promotional campaign", func(t *testing.T) {
+		data := parser.ParseDisclosurePage(loadFixture(t, "disclosure_paid_promotion.html"))
+		if promoted, _ := data["paid_promotion"].(bool); !promoted {
+			t.Error("Expected paid_promotion to be detected")
+		}
+	})
+
+	t.Run("Denied promotional activity", func(t *testing.T) {
+		data := parser.ParseDisclosurePage(loadFixture(t, "disclosure_no_promotion.html"))
+		if _, exists := data["paid_promotion"]; exists {
+			t.Errorf("Expected no paid_promotion, got %v", data["paid_promotion"])
+		}
+	})
+
+	t.Run("Overview promotion alert", func(t *testing.T) {
+		data := parser.ParseOverviewPage(loadFixture(t, "overview_promotion_alert.html"))
+		if promoted, _ := data["paid_promotion"].(bool); !promoted {
+			t.Error("Expected paid_promotion to be detected")
+		}
+	})
+}
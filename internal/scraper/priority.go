@@ -0,0 +1,105 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+)
+
+// ParsePriority resolves a client-supplied job priority, defaulting to normal
+func ParsePriority(raw string) (models.ScrapeJobPriority, error) {
+	switch models.ScrapeJobPriority(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", models.ScrapeJobPriorityNormal:
+		return models.ScrapeJobPriorityNormal, nil
+	case models.ScrapeJobPriorityHigh:
+		return models.ScrapeJobPriorityHigh, nil
+	case models.ScrapeJobPriorityLow:
+		return models.ScrapeJobPriorityLow, nil
+	default:
+		return "", fmt.Errorf("invalid priority %q; must be high, normal or low", raw)
+	}
+}
+
+// priorityRank orders priorities for scheduling; higher ranks are served first
+func priorityRank(priority models.ScrapeJobPriority) int {
+	switch priority {
+	case models.ScrapeJobPriorityHigh:
+		return 2
+	case models.ScrapeJobPriorityLow:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// slotScheduler hands out a fixed number of scrape slots shared by every running
+// job. A freed slot always goes to the highest-priority waiter, first come first
+// served within a priority, so a small urgent job isn't stuck behind a large batch.
+type slotScheduler struct {
+	mu      sync.Mutex
+	free    int
+	waiters [3][]chan struct{} // indexed by priorityRank
+}
+
+// newSlotScheduler creates a scheduler with the given number of slots
+func newSlotScheduler(slots int) *slotScheduler {
+	if slots < 1 {
+		slots = 1
+	}
+	return &slotScheduler{free: slots}
+}
+
+// Acquire blocks until a slot is granted to this priority or ctx is done
+func (s *slotScheduler) Acquire(ctx context.Context, priority models.ScrapeJobPriority) error {
+	rank := priorityRank(priority)
+
+	s.mu.Lock()
+	if s.free > 0 {
+		s.free--
+		s.mu.Unlock()
+		return nil
+	}
+	granted := make(chan struct{})
+	s.waiters[rank] = append(s.waiters[rank], granted)
+	s.mu.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, waiter := range s.waiters[rank] {
+			if waiter == granted {
+				s.waiters[rank] = append(s.waiters[rank][:i], s.waiters[rank][i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The slot was granted while we were giving up; pass it on
+		s.releaseLocked()
+		return ctx.Err()
+	}
+}
+
+// Release returns a slot, handing it straight to the highest-priority waiter
+func (s *slotScheduler) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked()
+}
+
+// releaseLocked releases a slot; callers hold s.mu
+func (s *slotScheduler) releaseLocked() {
+	for rank := len(s.waiters) - 1; rank >= 0; rank-- {
+		if len(s.waiters[rank]) > 0 {
+			next := s.waiters[rank][0]
+			s.waiters[rank] = s.waiters[rank][1:]
+			close(next)
+			return
+		}
+	}
+	s.free++
+}
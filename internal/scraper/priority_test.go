@@ -0,0 +1,115 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+)
+
+func TestParsePriority(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		expected models.ScrapeJobPriority
+		valid    bool
+	}{
+		{raw: "", expected: models.ScrapeJobPriorityNormal, valid: true},
+		{raw: "normal", expected: models.ScrapeJobPriorityNormal, valid: true},
+		{raw: " HIGH ", expected: models.ScrapeJobPriorityHigh, valid: true},
+		{raw: "low", expected: models.ScrapeJobPriorityLow, valid: true},
+		{raw: "urgent", valid: false},
+	}
+
+	for _, tc := range testCases {
+		priority, err := ParsePriority(tc.raw)
+		if tc.valid && (err != nil || priority != tc.expected) {
+			t.Errorf("ParsePriority(%q) = %q, %v; expected %q", tc.raw, priority, err, tc.expected)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("ParsePriority(%q) expected error, got %q", tc.raw, priority)
+		}
+	}
+}
+
+// waitForWaiters blocks until the scheduler has n queued waiters
+func waitForWaiters(t *testing.T, s *slotScheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		queued := 0
+		for _, waiters := range s.waiters {
+			queued += len(waiters)
+		}
+		s.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued waiters", n)
+}
+
+func TestSlotScheduler_ServesHigherPriorityFirst(t *testing.T) {
+	s := newSlotScheduler(1)
+	ctx := context.Background()
+
+	if err := s.Acquire(ctx, models.ScrapeJobPriorityNormal); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	order := make(chan models.ScrapeJobPriority, 3)
+	queue := []models.ScrapeJobPriority{
+		models.ScrapeJobPriorityLow,
+		models.ScrapeJobPriorityNormal,
+		models.ScrapeJobPriorityHigh,
+	}
+	for i, priority := range queue {
+		go func(priority models.ScrapeJobPriority) {
+			if err := s.Acquire(ctx, priority); err == nil {
+				order <- priority
+			}
+		}(priority)
+		waitForWaiters(t, s, i+1)
+	}
+
+	expected := []models.ScrapeJobPriority{
+		models.ScrapeJobPriorityHigh,
+		models.ScrapeJobPriorityNormal,
+		models.ScrapeJobPriorityLow,
+	}
+	for _, want := range expected {
+		s.Release()
+		select {
+		case got := <-order:
+			if got != want {
+				t.Errorf("Expected %s priority to be served next, got %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s priority slot", want)
+		}
+	}
+}
+
+func TestSlotScheduler_CancelledWaiterGivesUpSlot(t *testing.T) {
+	s := newSlotScheduler(1)
+	if err := s.Acquire(context.Background(), models.ScrapeJobPriorityLow); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- s.Acquire(ctx, models.ScrapeJobPriorityHigh) }()
+	waitForWaiters(t, s, 1)
+	cancel()
+
+	if err := <-errs; err == nil {
+		t.Fatal("Expected cancelled Acquire to return an error")
+	}
+
+	s.Release()
+	if s.free != 1 {
+		t.Errorf("Expected the released slot to be free, got %d free", s.free)
+	}
+}
@@ -0,0 +1,130 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Response headers OxyLabs (or a proxy in front of it) may use to report
+// remaining credits and rate limits, checked in order
+var (
+	remainingHeaders = []string{"X-RateLimit-Remaining", "X-Credits-Remaining", "X-Oxylabs-Credits-Remaining"}
+	limitHeaders     = []string{"X-RateLimit-Limit", "X-Credits-Limit", "X-Oxylabs-Credits-Limit"}
+	resetHeaders     = []string{"X-RateLimit-Reset", "X-Credits-Reset"}
+)
+
+// lowRateLimitFraction warns once remaining falls to this share of the limit
+const lowRateLimitFraction = 0.1
+
+// lowRateLimitRemaining warns at this many remaining requests when no limit is reported
+const lowRateLimitRemaining = 100
+
+// RateLimitInfo is the most recent rate limit / credit state reported by OxyLabs
+type RateLimitInfo struct {
+	Remaining  *int64     `json:"remaining,omitempty"`
+	Limit      *int64     `json:"limit,omitempty"`
+	ResetAt    *time.Time `json:"reset_at,omitempty"`
+	RetryAfter *time.Time `json:"retry_after,omitempty"`
+	ObservedAt time.Time  `json:"observed_at"`
+}
+
+// parseRateLimitHeaders extracts rate limit information from response headers.
+// It returns false when the response carries none.
+func parseRateLimitHeaders(header http.Header, now time.Time) (RateLimitInfo, bool) {
+	info := RateLimitInfo{ObservedAt: now}
+	found := false
+
+	if v, ok := headerInt(header, remainingHeaders); ok {
+		info.Remaining = &v
+		found = true
+	}
+	if v, ok := headerInt(header, limitHeaders); ok {
+		info.Limit = &v
+		found = true
+	}
+	if v, ok := headerInt(header, resetHeaders); ok {
+		reset := resetTime(v, now)
+		info.ResetAt = &reset
+		found = true
+	}
+	if retry := strings.TrimSpace(header.Get("Retry-After")); retry != "" {
+		if seconds, err := strconv.ParseInt(retry, 10, 64); err == nil {
+			at := now.Add(time.Duration(seconds) * time.Second)
+			info.RetryAfter = &at
+			found = true
+		} else if at, err := http.ParseTime(retry); err == nil {
+			info.RetryAfter = &at
+			found = true
+		}
+	}
+
+	return info, found
+}
+
+// headerInt returns the first of the named headers that holds an integer
+func headerInt(header http.Header, names []string) (int64, bool) {
+	for _, name := range names {
+		if raw := strings.TrimSpace(header.Get(name)); raw != "" {
+			if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// resetTime interprets a reset header as a Unix timestamp when it is large
+// enough to be one, and otherwise as seconds from now
+func resetTime(v int64, now time.Time) time.Time {
+	if v > 1_000_000_000 {
+		return time.Unix(v, 0)
+	}
+	return now.Add(time.Duration(v) * time.Second)
+}
+
+// IsLow reports whether remaining credits or requests are nearly exhausted
+func (r RateLimitInfo) IsLow() bool {
+	if r.Remaining == nil {
+		return false
+	}
+	if r.Limit != nil && *r.Limit > 0 {
+		return float64(*r.Remaining) <= float64(*r.Limit)*lowRateLimitFraction
+	}
+	return *r.Remaining <= lowRateLimitRemaining
+}
+
+// IsExhausted reports whether OxyLabs has asked us to stop until a later time
+func (r RateLimitInfo) IsExhausted(now time.Time) bool {
+	if r.RetryAfter != nil && r.RetryAfter.After(now) {
+		return true
+	}
+	if r.Remaining != nil && *r.Remaining <= 0 {
+		return r.ResetAt == nil || r.ResetAt.After(now)
+	}
+	return false
+}
+
+// String summarizes the rate limit state for logs
+func (r RateLimitInfo) String() string {
+	var parts []string
+	if r.Remaining != nil {
+		if r.Limit != nil {
+			parts = append(parts, fmt.Sprintf("%d of %d remaining", *r.Remaining, *r.Limit))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d remaining", *r.Remaining))
+		}
+	}
+	if r.ResetAt != nil {
+		parts = append(parts, "resets at "+r.ResetAt.Format(time.RFC3339))
+	}
+	if r.RetryAfter != nil {
+		parts = append(parts, "retry after "+r.RetryAfter.Format(time.RFC3339))
+	}
+	if len(parts) == 0 {
+		return "no limits reported"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,61 @@
+package scraper
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	header := http.Header{}
+	if _, ok := parseRateLimitHeaders(header, now); ok {
+		t.Error("expected no rate limit info without headers")
+	}
+
+	header.Set("X-Credits-Remaining", "0")
+	header.Set("X-RateLimit-Reset", "1700000600")
+	header.Set("Retry-After", "30")
+
+	info, ok := parseRateLimitHeaders(header, now)
+	if !ok {
+		t.Fatal("expected rate limit info")
+	}
+	if info.Remaining == nil || *info.Remaining != 0 {
+		t.Errorf("expected 0 remaining, got %v", info.Remaining)
+	}
+	if info.ResetAt == nil || !info.ResetAt.Equal(time.Unix(1_700_000_600, 0)) {
+		t.Errorf("expected epoch reset time, got %v", info.ResetAt)
+	}
+	if info.RetryAfter == nil || !info.RetryAfter.Equal(now.Add(30*time.Second)) {
+		t.Errorf("expected retry after 30s, got %v", info.RetryAfter)
+	}
+	if !info.IsExhausted(now) {
+		t.Error("expected exhausted rate limit")
+	}
+	if info.IsExhausted(now.Add(time.Hour)) {
+		t.Error("expected rate limit to recover after reset")
+	}
+}
+
+func TestApplyRateLimit(t *testing.T) {
+	now := time.Now()
+	remaining, limit := int64(5), int64(1000)
+
+	status := HealthStatus{IsHealthy: true}
+	applyRateLimit(&status, RateLimitInfo{Remaining: &remaining, Limit: &limit, ObservedAt: now}, now)
+	if !status.IsHealthy {
+		t.Error("low credits should warn without marking the scraper unhealthy")
+	}
+	if len(status.HealthIssues) != 1 || status.RateLimit == nil {
+		t.Errorf("expected a low-credit issue, got %v", status.HealthIssues)
+	}
+
+	retryAfter := now.Add(time.Minute)
+	status = HealthStatus{IsHealthy: true}
+	applyRateLimit(&status, RateLimitInfo{RetryAfter: &retryAfter, ObservedAt: now}, now)
+	if status.IsHealthy {
+		t.Error("expected exhausted rate limit to mark the scraper unhealthy")
+	}
+}
@@ -0,0 +1,230 @@
+package scraper
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/google/uuid"
+)
+
+// retransformTimeout bounds a whole re-transform job
+const retransformTimeout = 2 * time.Hour
+
+// RetransformCompanies rebuilds each company from the parsed page fields of its most
+// recent stored scrape with the current transformer, recomputes derived flags and
+// re-scores it, all in one job. No OxyLabs requests are made, so it applies transformer,
+// tier alias and scoring changes to companies that were already scraped. It does not
+// re-run the parser: snapshots hold parsed fields rather than raw HTML, so parser and
+// selector changes need a rescrape.
+func (s *Service) RetransformCompanies(ctx context.Context, tickers []string, userID uuid.UUID) (*models.ScrapeJob, error) {
+	log.Printf("Starting re-transform for %d tickers", len(tickers))
+
+	job := &models.ScrapeJob{
+		ID:           uuid.New(),
+		Status:       string(models.ScrapeJobPending),
+		TotalTickers: len(tickers),
+		StartedBy:    userID,
+		StartedAt:    time.Now(),
+		Priority:     string(models.ScrapeJobPriorityNormal),
+	}
+
+	if err := s.createScrapeJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create re-transform job: %w", err)
+	}
+
+	job.Status = string(models.ScrapeJobRunning)
+	if err := s.updateScrapeJob(ctx, job); err != nil {
+		log.Printf("Failed to update job status to running: %v", err)
+	}
+
+	// The job outlives the request that started it
+	go func() {
+		jobCtx, cancel := context.WithTimeout(context.Background(), retransformTimeout)
+		defer cancel()
+
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Panic in re-transform goroutine: %v", r)
+				job.Status = string(models.ScrapeJobFailed)
+				job.ErrorMessage = fmt.Sprintf("panic: %v", r)
+				s.publishJobEvent(job)
+			}
+		}()
+
+		var failures []string
+		for i, ticker := range tickers {
+			if err := jobCtx.Err(); err != nil {
+				job.ErrorMessage = fmt.Sprintf("stopped after %d of %d tickers: %v", i, len(tickers), err)
+				break
+			}
+
+			if err := s.retransformCompany(jobCtx, ticker); err != nil {
+				log.Printf("Failed to re-transform ticker %s: %v", ticker, err)
+				job.FailedTickers++
+				failures = append(failures, ticker)
+			} else {
+				job.ProcessedTickers++
+			}
+
+			if (i+1)%10 == 0 {
+				if err := s.updateScrapeJob(jobCtx, job); err != nil {
+					log.Printf("Failed to update job progress: %v", err)
+				}
+			}
+		}
+
+		if job.ErrorMessage != "" {
+			job.Status = string(models.ScrapeJobFailed)
+		} else {
+			job.Status = string(models.ScrapeJobCompleted)
+			if len(failures) > 0 {
+				job.ErrorMessage = "failed tickers: " + strings.Join(failures, ", ")
+			}
+		}
+
+		completedAt := time.Now()
+		job.CompletedAt = &completedAt
+
+		// Record the final status even if the job ran out of time
+		finalCtx, finalCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer finalCancel()
+		if err := s.updateScrapeJob(finalCtx, job); err != nil {
+			log.Printf("Failed to update final job status: %v", err)
+		}
+
+		log.Printf("Re-transform completed. Processed: %d, Failed: %d", job.ProcessedTickers, job.FailedTickers)
+		s.publishJobEvent(job)
+	}()
+
+	return job, nil
+}
+
+// retransformCompany rebuilds one company from its latest snapshot and re-scores it
+func (s *Service) retransformCompany(ctx context.Context, ticker string) error {
+	var companyID uuid.UUID
+	var missingScrapes int
+	var snapshotJSON []byte
+	err := s.db.QueryRowContext(ctx, `
+		SELECT c.id, c.filing_dates_missing_scrapes, h.snapshot_data
+		FROM companies c
+		JOIN company_history h ON h.company_id = c.id
+		WHERE c.ticker = $1
+		ORDER BY h.scraped_at DESC
+		LIMIT 1`,
+		strings.ToUpper(ticker),
+	).Scan(&companyID, &missingScrapes, &snapshotJSON)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no stored scrape for %s", ticker)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load latest snapshot: %w", err)
+	}
+
+	var snapshot struct {
+		ScrapedData *models.ScrapedData `json:"scraped_data"`
+	}
+	if err := json.Unmarshal(snapshotJSON, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snapshot.ScrapedData == nil {
+		return fmt.Errorf("snapshot has no scraped data")
+	}
+
+	for _, page := range []map[string]interface{}{
+		snapshot.ScrapedData.Overview, snapshot.ScrapedData.Financials, snapshot.ScrapedData.Disclosure,
+	} {
+		restoreParsedTypes(page)
+	}
+
+	company, err := s.transformer.TransformToCompany(snapshot.ScrapedData)
+	if err != nil {
+		return fmt.Errorf("failed to transform snapshot: %w", err)
+	}
+	if validationErrors := s.transformer.ValidateCompanyData(company); len(validationErrors) > 0 {
+		log.Printf("Validation warnings for ticker %s: %v", ticker, validationErrors)
+	}
+
+	company.ID = companyID
+	company.FilingDatesUncertain = (company.Last10KDate == nil || company.Last10QDate == nil) &&
+		missingScrapes < s.cfg.DelinquencyConfirmationScrapes
+
+	if err := s.updateRetransformedCompany(ctx, company); err != nil {
+		return err
+	}
+
+	if err := s.scoreCompanyAfterScrape(ctx, companyID.String()); err != nil {
+		return fmt.Errorf("updated but failed to re-score: %w", err)
+	}
+	return nil
+}
+
+// updateRetransformedCompany writes rebuilt fields without recording a new history
+// snapshot or advancing the missing-filing-date count, since nothing was scraped
+func (s *Service) updateRetransformedCompany(ctx context.Context, company *models.Company) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE companies SET
+			company_name = $2, market_tier = $3, quote_status = $4, trading_volume = $5,
+			website = $6, description = $7, officers = $8, address = $9,
+			transfer_agent = $10, auditor = $11, last_10k_date = $12, last_10q_date = $13,
+			last_filing_date = $14, profile_verified = $15, reporting_status = $16,
+			filing_dates_uncertain = $17, updated_at = $18,
+			cusip = COALESCE(NULLIF($19, ''), cusip), paid_promotion = $20, market_tier_raw = $21
+		WHERE id = $1`,
+		company.ID, company.CompanyName, company.MarketTier, company.QuoteStatus,
+		company.TradingVolume, company.Website, company.Description,
+		company.Officers, company.Address, company.TransferAgent, company.Auditor,
+		company.Last10KDate, company.Last10QDate, company.LastFilingDate,
+		company.ProfileVerified, company.ReportingStatus, company.FilingDatesUncertain,
+		time.Now(), company.CUSIP, company.PaidPromotion, company.MarketTierRaw,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+	return nil
+}
+
+// restoreParsedTypes converts page fields decoded from snapshot JSON back to the
+// types the parser produces, which TransformToCompany asserts on
+func restoreParsedTypes(page map[string]interface{}) {
+	for key, value := range page {
+		switch key {
+		case "trading_volume":
+			if volume, ok := value.(float64); ok {
+				page[key] = int64(volume)
+			}
+		case "last_10k_date", "last_10q_date", "last_filing_date":
+			if raw, ok := value.(string); ok {
+				if date, err := time.Parse(time.RFC3339, raw); err == nil {
+					page[key] = &date
+				} else {
+					delete(page, key)
+				}
+			}
+		case "officers":
+			var officers []models.Officer
+			if remarshal(value, &officers) == nil {
+				page[key] = officers
+			}
+		case "address":
+			var address models.Address
+			if remarshal(value, &address) == nil {
+				page[key] = address
+			}
+		}
+	}
+}
+
+// remarshal decodes a generic JSON value into target
+func remarshal(value interface{}, target interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+)
+
+func TestRestoreParsedTypes_RoundTripsThroughTransformer(t *testing.T) {
+	filed := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	original := &models.ScrapedData{
+		Ticker: "ABCD",
+		Overview: map[string]interface{}{
+			"company_name":   "ABCD Holdings",
+			"market_tier":    "Pink Limited",
+			"trading_volume": int64(12500),
+			"officers":       []models.Officer{{Name: "Jane Doe", Title: "CEO", Location: "Hong Kong"}},
+			"address":        models.Address{City: "Reno", State: "NV"},
+		},
+		Financials: map[string]interface{}{
+			"last_10k_date": &filed,
+		},
+		Disclosure: map[string]interface{}{
+			"paid_promotion": true,
+		},
+	}
+
+	// Simulate storing the snapshot and reading it back
+	raw, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded models.ScrapedData
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, page := range []map[string]interface{}{decoded.Overview, decoded.Financials, decoded.Disclosure} {
+		restoreParsedTypes(page)
+	}
+
+	company, err := NewTransformer().TransformToCompany(&decoded)
+	if err != nil {
+		t.Fatalf("transform: %v", err)
+	}
+
+	if company.TradingVolume != 12500 {
+		t.Errorf("Expected trading volume 12500, got %d", company.TradingVolume)
+	}
+	if len(company.Officers) != 1 || company.Officers[0].Name != "Jane Doe" {
+		t.Errorf("Expected officers to survive the round trip, got %+v", company.Officers)
+	}
+	if company.Address.City != "Reno" {
+		t.Errorf("Expected address to survive the round trip, got %+v", company.Address)
+	}
+	if company.Last10KDate == nil || !company.Last10KDate.Equal(filed) {
+		t.Errorf("Expected 10-K date %v, got %v", filed, company.Last10KDate)
+	}
+	if company.MarketTier != models.MarketTierPinkLimited {
+		t.Errorf("Expected normalized tier %q, got %q", models.MarketTierPinkLimited, company.MarketTier)
+	}
+	if !company.PaidPromotion {
+		t.Error("Expected paid promotion flag to survive the round trip")
+	}
+}
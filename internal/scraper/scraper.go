@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +20,7 @@ type Scraper struct {
 	parser         *Parser
 	maxConcurrency int
 	healthMonitor  *HealthMonitor
+	slots          *slotScheduler // shared by all batch jobs, served by priority
 }
 
 // New creates a new scraper instance with OxyLabs client
@@ -30,6 +34,7 @@ func New(cfg *config.Config, maxConcurrency int) (*Scraper, error) {
 		parser:         NewParser(),
 		maxConcurrency: maxConcurrency,
 		healthMonitor:  NewHealthMonitor(),
+		slots:          newSlotScheduler(maxConcurrency),
 	}, nil
 }
 
@@ -53,6 +58,7 @@ func (s *Scraper) ScrapeTicker(ctx context.Context, ticker string) (*models.Scra
 
 	// Use batch request for efficiency
 	docs, errors := s.client.GetBatch(ctx, urls)
+	scraped.OxyLabsRequests = len(urls)
 
 	// Track overall success/failure
 	hasErrors := false
@@ -108,28 +114,25 @@ func (s *Scraper) ScrapeTicker(ctx context.Context, ticker string) (*models.Scra
 	return scraped, nil
 }
 
-// ScrapeTickersBatch scrapes multiple tickers concurrently
-func (s *Scraper) ScrapeTickersBatch(ctx context.Context, tickers []string, resultsChan chan<- *models.ScrapedData) error {
+// ScrapeTickersBatch scrapes multiple tickers concurrently, competing with other
+// running jobs for scraper slots at the given priority
+func (s *Scraper) ScrapeTickersBatch(ctx context.Context, tickers []string, priority models.ScrapeJobPriority, resultsChan chan<- *models.ScrapedData) error {
 	defer close(resultsChan)
 
-	// Create semaphore for concurrency control
-	semaphore := make(chan struct{}, s.maxConcurrency)
 	var wg sync.WaitGroup
 
 	for _, ticker := range tickers {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
+		// Acquire a slot before spawning so only maxConcurrency goroutines
+		// (and their results) exist at once, however many tickers there are
+		if err := s.slots.Acquire(ctx, priority); err != nil {
+			wg.Wait()
+			return err
 		}
 
 		wg.Add(1)
 		go func(t string) {
 			defer wg.Done()
-
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+			defer s.slots.Release()
 
 			// Scrape ticker
 			scraped, err := s.ScrapeTicker(ctx, t)
@@ -158,8 +161,9 @@ func (s *Scraper) ScrapeTickersBatch(ctx context.Context, tickers []string, resu
 	return nil
 }
 
-// ScrapeTickersOptimized uses a more efficient approach for large batches
-func (s *Scraper) ScrapeTickersOptimized(ctx context.Context, tickers []string, resultsChan chan<- *models.ScrapedData) error {
+// ScrapeTickersOptimized uses a more efficient approach for large batches. Each
+// batch request holds one scraper slot, acquired at the given priority.
+func (s *Scraper) ScrapeTickersOptimized(ctx context.Context, tickers []string, priority models.ScrapeJobPriority, resultsChan chan<- *models.ScrapedData) error {
 	defer close(resultsChan)
 
 	// For large batches, we can group multiple tickers and use OxyLabs batch API more efficiently
@@ -172,7 +176,12 @@ func (s *Scraper) ScrapeTickersOptimized(ctx context.Context, tickers []string,
 		}
 		
 		batch := tickers[i:end]
-		if err := s.processBatch(ctx, batch, resultsChan); err != nil {
+		if err := s.slots.Acquire(ctx, priority); err != nil {
+			return err
+		}
+		err := s.processBatch(ctx, batch, resultsChan)
+		s.slots.Release()
+		if err != nil {
 			log.Printf("Error processing batch %d-%d: %v", i, end, err)
 		}
 		
@@ -192,6 +201,7 @@ func (s *Scraper) processBatch(ctx context.Context, tickers []string, resultsCha
 	// Build all URLs for the batch
 	var allURLs []string
 	tickerIndexMap := make(map[string]int) // URL to ticker index mapping
+	requestsPerTicker := make(map[string]int)
 	
 	for i, ticker := range tickers {
 		urls := []string{
@@ -199,6 +209,7 @@ func (s *Scraper) processBatch(ctx context.Context, tickers []string, resultsCha
 			fmt.Sprintf("https://www.otcmarkets.com/stock/%s/financials", ticker),
 			fmt.Sprintf("https://www.otcmarkets.com/stock/%s/disclosure", ticker),
 		}
+		requestsPerTicker[ticker] = len(urls)
 		
 		for j, url := range urls {
 			allURLs = append(allURLs, url)
@@ -212,12 +223,13 @@ func (s *Scraper) processBatch(ctx context.Context, tickers []string, resultsCha
 	// Process results for each ticker
 	for _, ticker := range tickers {
 		scraped := &models.ScrapedData{
-			Ticker:     ticker,
-			ScrapedAt:  time.Now(),
-			Overview:   make(map[string]interface{}),
-			Financials: make(map[string]interface{}),
-			Disclosure: make(map[string]interface{}),
-			Errors:     []string{},
+			Ticker:          ticker,
+			ScrapedAt:       time.Now(),
+			Overview:        make(map[string]interface{}),
+			Financials:      make(map[string]interface{}),
+			Disclosure:      make(map[string]interface{}),
+			Errors:          []string{},
+			OxyLabsRequests: requestsPerTicker[ticker],
 		}
 
 		// Get URLs for this ticker
@@ -257,14 +269,67 @@ func (s *Scraper) processBatch(ctx context.Context, tickers []string, resultsCha
 	return nil
 }
 
+// Page types understood by the parser
+const (
+	PageTypeOverview   = "overview"
+	PageTypeFinancials = "financials"
+	PageTypeDisclosure = "disclosure"
+)
+
+// ResolvePageType validates that rawURL is an OTC Markets page and returns the
+// page type to parse it as. An empty pageType is inferred from the URL path.
+func ResolvePageType(rawURL, pageType string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "https" || (u.Host != "www.otcmarkets.com" && u.Host != "otcmarkets.com") {
+		return "", fmt.Errorf("URL must be an https://www.otcmarkets.com page")
+	}
+
+	if pageType == "" {
+		pageType = path.Base(u.Path)
+	}
+
+	switch pageType = strings.ToLower(strings.TrimSpace(pageType)); pageType {
+	case PageTypeOverview, PageTypeFinancials, PageTypeDisclosure:
+		return pageType, nil
+	default:
+		return "", fmt.Errorf("page_type must be one of %s, %s or %s", PageTypeOverview, PageTypeFinancials, PageTypeDisclosure)
+	}
+}
+
+// ParsePage fetches a single URL and runs the parser for the given page type
+func (s *Scraper) ParsePage(ctx context.Context, rawURL, pageType string) (map[string]interface{}, error) {
+	doc, err := s.client.Get(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+
+	switch pageType {
+	case PageTypeOverview:
+		return s.parser.ParseOverviewPage(doc), nil
+	case PageTypeFinancials:
+		return s.parser.ParseFinancialsPage(doc), nil
+	case PageTypeDisclosure:
+		return s.parser.ParseDisclosurePage(doc), nil
+	default:
+		return nil, fmt.Errorf("unknown page type %q", pageType)
+	}
+}
+
 // GetHealthStatus returns the current health status of the scraper
 func (s *Scraper) GetHealthStatus() HealthStatus {
-	return s.healthMonitor.GetHealthStatus()
+	status := s.healthMonitor.GetHealthStatus()
+	if info, ok := s.client.RateLimit(); ok {
+		applyRateLimit(&status, info, time.Now())
+	}
+	return status
 }
 
 // IsHealthy returns true if the scraper is operating within healthy parameters
 func (s *Scraper) IsHealthy() bool {
-	return s.healthMonitor.IsHealthy()
+	return s.GetHealthStatus().IsHealthy
 }
 
 // GetFailureRate returns the current failure rate as a percentage
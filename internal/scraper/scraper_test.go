@@ -0,0 +1,88 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+func TestResolvePageType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		pageType string
+		expected string
+		wantErr  bool
+	}{
+		{"Inferred from path", "https://www.otcmarkets.com/stock/ABCD/disclosure", "", PageTypeDisclosure, false},
+		{"Explicit page type", "https://www.otcmarkets.com/stock/ABCD/security", "Overview", PageTypeOverview, false},
+		{"Bare domain", "https://otcmarkets.com/stock/ABCD/financials", "", PageTypeFinancials, false},
+		{"Unknown page type", "https://www.otcmarkets.com/stock/ABCD/news", "", "", true},
+		{"Other host", "https://example.com/stock/ABCD/overview", "", "", true},
+		{"Plain http", "http://www.otcmarkets.com/stock/ABCD/overview", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResolvePageType(tc.url, tc.pageType)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got page type %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScrapeTickersOptimized_CountsOxyLabsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []OxyLabsRequest
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results := make([]string, len(requests))
+		for i := range requests {
+			results[i] = `{"content":"<html><body>ok</body></html>","status_code":200}`
+		}
+		fmt.Fprintf(w, `{"results":[%s]}`, strings.Join(results, ","))
+	}))
+	defer server.Close()
+
+	s := &Scraper{
+		client:        NewOxyLabsClient(&config.Config{OxyLabsEndpoint: server.URL, OxyLabsRequestTimeoutSeconds: 5}),
+		parser:        NewParser(),
+		healthMonitor: NewHealthMonitor(),
+		slots:         newSlotScheduler(1),
+	}
+
+	resultsChan := make(chan *models.ScrapedData, 2)
+	if err := s.ScrapeTickersOptimized(context.Background(), []string{"ABCD", "WXYZ"}, models.ScrapeJobPriorityNormal, resultsChan); err != nil {
+		t.Fatalf("ScrapeTickersOptimized failed: %v", err)
+	}
+
+	total := 0
+	for scraped := range resultsChan {
+		if scraped.OxyLabsRequests != 3 {
+			t.Errorf("Expected 3 OxyLabs requests for %s, got %d", scraped.Ticker, scraped.OxyLabsRequests)
+		}
+		total += scraped.OxyLabsRequests
+	}
+	if total != 6 {
+		t.Errorf("Expected 6 OxyLabs requests in total, got %d", total)
+	}
+}
@@ -13,9 +13,17 @@ import (
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/services"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/webhook"
 	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
+// resultsBufferSize bounds scraped results waiting to be stored
+const resultsBufferSize = 32
+
+// postScrapeScoringConcurrency bounds background scorings started by batch jobs,
+// so a large job can't flood the database pool with scoring work
+const postScrapeScoringConcurrency = 4
+
 // Service provides high-level scraping operations with OxyLabs integration
 type Service struct {
 	db             *database.DB
@@ -23,6 +31,23 @@ type Service struct {
 	transformer    *Transformer
 	cfg            *config.Config
 	scoringService services.ScoringService
+	edgar          *EDGARResolver
+	webhooks       *webhook.Dispatcher
+	scoringSlots   chan struct{} // bounds post-scrape scoring goroutines
+}
+
+// JobEvent is the webhook payload sent when a scrape job finishes
+type JobEvent struct {
+	JobID            uuid.UUID  `json:"job_id"`
+	Status           string     `json:"status"`
+	TotalTickers     int        `json:"total_tickers"`
+	ProcessedTickers int        `json:"processed_tickers"`
+	FailedTickers    int        `json:"failed_tickers"`
+	StartedBy        uuid.UUID  `json:"started_by"`
+	StartedAt        time.Time  `json:"started_at"`
+	CompletedAt      *time.Time `json:"completed_at"`
+	DurationSeconds  float64    `json:"duration_seconds"`
+	ErrorMessage     string     `json:"error_message,omitempty"`
 }
 
 // NewService creates a new scraping service with OxyLabs support
@@ -39,18 +64,25 @@ func NewService(db *database.DB, cfg *config.Config, maxConcurrency int) (*Servi
 	return &Service{
 		db:             db,
 		scraper:        scraper,
-		transformer:    NewTransformer(),
+		transformer:    NewTransformerWithTierAliases(cfg.GetMarketTierAliases()),
 		cfg:            cfg,
 		scoringService: scoringService,
+		edgar:          NewEDGARResolver(cfg),
+		webhooks:       webhook.NewDispatcher(cfg),
+		scoringSlots:   make(chan struct{}, postScrapeScoringConcurrency),
 	}, nil
 }
 
-// ScrapeAndStore scrapes a single ticker and stores it in the database
-func (s *Service) ScrapeAndStore(ctx context.Context, ticker string) (*models.Company, error) {
+// ScrapeAndStore scrapes a single ticker and stores it in the database. source is
+// the OxyLabsUsage* value its requests are recorded under for credit reporting.
+func (s *Service) ScrapeAndStore(ctx context.Context, ticker, source string) (*models.Company, error) {
 	log.Printf("Starting scrape for ticker: %s", ticker)
 
 	// Scrape the ticker using OxyLabs
 	scraped, err := s.scraper.ScrapeTicker(ctx, ticker)
+	if scraped != nil {
+		s.recordOxyLabsUsage(ctx, source, ticker, scraped.OxyLabsRequests)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to scrape ticker %s: %w", ticker, err)
 	}
@@ -87,8 +119,72 @@ func (s *Service) ScrapeAndStore(ctx context.Context, ticker string) (*models.Co
 }
 
 // ScrapeTickersBatch processes multiple tickers in a single job using optimized batching
-func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, userID uuid.UUID, useOptimized bool) (*models.ScrapeJob, error) {
-	log.Printf("Starting batch scrape for %d tickers", len(tickers))
+func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, userID uuid.UUID, useOptimized bool, priority models.ScrapeJobPriority) (*models.ScrapeJob, error) {
+	return s.startBatchJob(ctx, tickers, nil, userID, useOptimized, priority)
+}
+
+// CIKImportResult describes a CIK-based import
+type CIKImportResult struct {
+	Job        *models.ScrapeJob `json:"job,omitempty"`
+	Resolved   map[string]string `json:"resolved"`   // CIK -> ticker
+	Unresolved []string          `json:"unresolved"` // CIKs with no listed ticker
+	Invalid    []string          `json:"invalid"`    // Inputs that are not valid CIKs
+}
+
+// ImportByCIK resolves CIKs to current tickers via EDGAR and scrapes them in a
+// single job, storing each CIK on its company for later cross-reference
+func (s *Service) ImportByCIK(ctx context.Context, rawCIKs []string, userID uuid.UUID, useOptimized bool, priority models.ScrapeJobPriority) (*CIKImportResult, error) {
+	result := &CIKImportResult{Invalid: []string{}}
+
+	var ciks []string
+	seen := make(map[string]bool)
+	for _, raw := range rawCIKs {
+		cik, err := NormalizeCIK(raw)
+		if err != nil {
+			result.Invalid = append(result.Invalid, raw)
+			continue
+		}
+		if !seen[cik] {
+			seen[cik] = true
+			ciks = append(ciks, cik)
+		}
+	}
+
+	resolved, unresolved, err := s.edgar.ResolveTickers(ctx, ciks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve CIKs: %w", err)
+	}
+	result.Resolved = resolved
+	result.Unresolved = unresolved
+
+	if len(resolved) == 0 {
+		return result, nil
+	}
+
+	cikByTicker := make(map[string]string, len(resolved))
+	tickers := make([]string, 0, len(resolved))
+	for _, cik := range ciks {
+		if ticker, ok := resolved[cik]; ok {
+			if _, dup := cikByTicker[ticker]; !dup {
+				tickers = append(tickers, ticker)
+			}
+			cikByTicker[ticker] = cik
+		}
+	}
+
+	job, err := s.startBatchJob(ctx, tickers, cikByTicker, userID, useOptimized, priority)
+	if err != nil {
+		return nil, err
+	}
+	result.Job = job
+
+	return result, nil
+}
+
+// startBatchJob creates a scrape job and processes tickers in the background.
+// cikByTicker optionally attaches a known CIK to each stored company.
+func (s *Service) startBatchJob(ctx context.Context, tickers []string, cikByTicker map[string]string, userID uuid.UUID, useOptimized bool, priority models.ScrapeJobPriority) (*models.ScrapeJob, error) {
+	log.Printf("Starting %s priority batch scrape for %d tickers", priority, len(tickers))
 
 	// Create scrape job record
 	job := &models.ScrapeJob{
@@ -99,6 +195,7 @@ func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, user
 		FailedTickers:    0,
 		StartedBy:        userID,
 		StartedAt:        time.Now(),
+		Priority:         string(priority),
 	}
 
 	if err := s.createScrapeJob(ctx, job); err != nil {
@@ -111,8 +208,9 @@ func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, user
 		log.Printf("Failed to update job status to running: %v", err)
 	}
 
-	// Create results channel
-	resultsChan := make(chan *models.ScrapedData, len(tickers))
+	// Small bounded buffer: scrapers block while the consumer stores results, so
+	// memory stays flat regardless of how many tickers were uploaded
+	resultsChan := make(chan *models.ScrapedData, resultsBufferSize)
 
 	// Start scraping in background
 	go func() {
@@ -121,44 +219,56 @@ func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, user
 				log.Printf("Panic in scraping goroutine: %v", r)
 				job.Status = string(models.ScrapeJobFailed)
 				job.ErrorMessage = fmt.Sprintf("panic: %v", r)
+				s.publishJobEvent(job)
 			}
 		}()
 
-		var err error
-		if useOptimized && len(tickers) > 10 {
-			// Use optimized batch processing for large sets
-			log.Printf("Using optimized batch processing for %d tickers", len(tickers))
-			err = s.scraper.ScrapeTickersOptimized(ctx, tickers, resultsChan)
-		} else {
-			// Use standard concurrent processing
-			log.Printf("Using standard concurrent processing for %d tickers", len(tickers))
-			err = s.scraper.ScrapeTickersBatch(ctx, tickers, resultsChan)
-		}
+		// Scrape in a separate goroutine so results are drained as they arrive.
+		// Both scrape methods close resultsChan when they return.
+		scrapeErr := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Panic in scraper: %v", r)
+					scrapeErr <- fmt.Errorf("panic: %v", r)
+				}
+			}()
 
-		if err != nil {
-			log.Printf("Error in batch scraping: %v", err)
-			job.Status = string(models.ScrapeJobFailed)
-			job.ErrorMessage = err.Error()
-		} else {
-			job.Status = string(models.ScrapeJobCompleted)
-		}
+			if useOptimized && len(tickers) > 10 {
+				// Use optimized batch processing for large sets
+				log.Printf("Using optimized batch processing for %d tickers", len(tickers))
+				scrapeErr <- s.scraper.ScrapeTickersOptimized(ctx, tickers, priority, resultsChan)
+			} else {
+				// Use standard concurrent processing
+				log.Printf("Using standard concurrent processing for %d tickers", len(tickers))
+				scrapeErr <- s.scraper.ScrapeTickersBatch(ctx, tickers, priority, resultsChan)
+			}
+		}()
 
 		// Process results
 		processedCount := 0
 		failedCount := 0
 
 		for scraped := range resultsChan {
+			job.OxyLabsRequests += scraped.OxyLabsRequests
+
 			if company, err := s.transformer.TransformToCompany(scraped); err != nil {
 				log.Printf("Failed to transform ticker %s: %v", scraped.Ticker, err)
 				failedCount++
 			} else {
+				if cik, ok := cikByTicker[scraped.Ticker]; ok {
+					company.CIK = cik
+				}
 				if err := s.storeCompany(ctx, company, scraped); err != nil {
 					log.Printf("Failed to store ticker %s: %v", scraped.Ticker, err)
 					failedCount++
 				} else {
 					processedCount++
-					// Automatically score the company after storing (non-blocking)
+					// Automatically score the company after storing. Waiting for a
+					// scoring slot applies backpressure instead of piling up goroutines.
+					s.scoringSlots <- struct{}{}
 					go func(companyID string, ticker string) {
+						defer func() { <-s.scoringSlots }()
 						if err := s.scoreCompanyAfterScrape(ctx, companyID); err != nil {
 							log.Printf("Warning: Failed to score company %s after batch scraping: %v", ticker, err)
 						}
@@ -176,6 +286,14 @@ func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, user
 			}
 		}
 
+		if err := <-scrapeErr; err != nil {
+			log.Printf("Error in batch scraping: %v", err)
+			job.Status = string(models.ScrapeJobFailed)
+			job.ErrorMessage = err.Error()
+		} else {
+			job.Status = string(models.ScrapeJobCompleted)
+		}
+
 		// Update final job status
 		job.ProcessedTickers = processedCount
 		job.FailedTickers = failedCount
@@ -187,14 +305,40 @@ func (s *Service) ScrapeTickersBatch(ctx context.Context, tickers []string, user
 		}
 
 		log.Printf("Batch scrape completed. Processed: %d, Failed: %d", processedCount, failedCount)
+		s.publishJobEvent(job)
 	}()
 
 	return job, nil
 }
 
+// publishJobEvent notifies webhook subscribers that a job reached a final status
+func (s *Service) publishJobEvent(job *models.ScrapeJob) {
+	eventType := webhook.EventScrapeJobCompleted
+	if job.Status == string(models.ScrapeJobFailed) {
+		eventType = webhook.EventScrapeJobFailed
+	}
+
+	event := JobEvent{
+		JobID:            job.ID,
+		Status:           job.Status,
+		TotalTickers:     job.TotalTickers,
+		ProcessedTickers: job.ProcessedTickers,
+		FailedTickers:    job.FailedTickers,
+		StartedBy:        job.StartedBy,
+		StartedAt:        job.StartedAt,
+		CompletedAt:      job.CompletedAt,
+		ErrorMessage:     job.ErrorMessage,
+	}
+	if job.CompletedAt != nil {
+		event.DurationSeconds = job.CompletedAt.Sub(job.StartedAt).Seconds()
+	}
+
+	s.webhooks.Publish(eventType, event)
+}
+
 // ScrapeTickerSingle is a convenience method for single ticker scraping
 func (s *Service) ScrapeTickerSingle(ctx context.Context, ticker string, userID uuid.UUID) (*models.ScrapeJob, error) {
-	return s.ScrapeTickersBatch(ctx, []string{ticker}, userID, false)
+	return s.ScrapeTickersBatch(ctx, []string{ticker}, userID, false, models.ScrapeJobPriorityHigh)
 }
 
 // storeCompany stores company data and historical snapshot with better error handling
@@ -208,38 +352,90 @@ func (s *Service) storeCompany(ctx context.Context, company *models.Company, scr
 	// Check if company exists
 	var existingID uuid.UUID
 	var existingUpdatedAt time.Time
+	var previousMissingScrapes int
 	err = tx.QueryRowContext(ctx,
-		"SELECT id, updated_at FROM companies WHERE ticker = $1",
+		"SELECT id, updated_at, filing_dates_missing_scrapes FROM companies WHERE ticker = $1",
 		company.Ticker,
-	).Scan(&existingID, &existingUpdatedAt)
+	).Scan(&existingID, &existingUpdatedAt, &previousMissingScrapes)
+
+	// Fall back to the CUSIP or CIK so a ticker change updates the existing
+	// company instead of creating a duplicate
+	if err == sql.ErrNoRows && (company.CUSIP != "" || company.CIK != "") {
+		var previousTicker string
+		err = tx.QueryRowContext(ctx, `
+			SELECT id, ticker, updated_at, filing_dates_missing_scrapes FROM companies
+			WHERE ($1 <> '' AND cusip = $1) OR ($2 <> '' AND cik = $2)
+			ORDER BY updated_at DESC LIMIT 1`,
+			company.CUSIP, company.CIK,
+		).Scan(&existingID, &previousTicker, &existingUpdatedAt, &previousMissingScrapes)
+		if err == nil {
+			log.Printf("Ticker change detected (CUSIP %q, CIK %q): %s -> %s", company.CUSIP, company.CIK, previousTicker, company.Ticker)
+		}
+	}
+
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check existing company: %w", err)
+	}
+
+	// Track consecutive scrapes without extractable 10-K/10-Q dates so a
+	// parser miss isn't immediately scored as a delinquent filer
+	missingScrapes := s.trackMissingFilingDates(company, previousMissingScrapes)
 
 	if err == sql.ErrNoRows {
-		// Insert new company
+		// Insert new company. A concurrent job may insert the same ticker between
+		// the lookup above and here, so fall through to an update on conflict,
+		// advancing that row's missing-filing-date count as the update path would.
 		company.ID = uuid.New()
 		company.CreatedAt = time.Now()
-		
-		_, err = tx.ExecContext(ctx, `
+
+		var inserted bool
+		err = tx.QueryRowContext(ctx, `
 			INSERT INTO companies (
 				id, ticker, company_name, market_tier, quote_status, trading_volume,
 				website, description, officers, address, transfer_agent, auditor,
 				last_10k_date, last_10q_date, last_filing_date, profile_verified,
-				created_at, updated_at
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+				reporting_status, filing_dates_uncertain, filing_dates_missing_scrapes,
+				cusip, cik, paid_promotion, market_tier_raw, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
+			ON CONFLICT (ticker) DO UPDATE SET
+				company_name = EXCLUDED.company_name, market_tier = EXCLUDED.market_tier,
+				quote_status = EXCLUDED.quote_status, trading_volume = EXCLUDED.trading_volume,
+				website = EXCLUDED.website, description = EXCLUDED.description,
+				officers = EXCLUDED.officers, address = EXCLUDED.address,
+				transfer_agent = EXCLUDED.transfer_agent, auditor = EXCLUDED.auditor,
+				last_10k_date = EXCLUDED.last_10k_date, last_10q_date = EXCLUDED.last_10q_date,
+				last_filing_date = EXCLUDED.last_filing_date, profile_verified = EXCLUDED.profile_verified,
+				reporting_status = EXCLUDED.reporting_status,
+				filing_dates_missing_scrapes = CASE WHEN EXCLUDED.filing_dates_missing_scrapes = 0
+					THEN 0 ELSE companies.filing_dates_missing_scrapes + 1 END,
+				filing_dates_uncertain = EXCLUDED.filing_dates_missing_scrapes <> 0
+					AND companies.filing_dates_missing_scrapes + 1 < $26,
+				cusip = COALESCE(NULLIF(EXCLUDED.cusip, ''), companies.cusip),
+				cik = COALESCE(NULLIF(EXCLUDED.cik, ''), companies.cik),
+				paid_promotion = EXCLUDED.paid_promotion, market_tier_raw = EXCLUDED.market_tier_raw,
+				updated_at = EXCLUDED.updated_at
+			RETURNING id, created_at, filing_dates_uncertain, xmax = 0`,
 			company.ID, company.Ticker, company.CompanyName, company.MarketTier,
 			company.QuoteStatus, company.TradingVolume, company.Website,
 			company.Description, company.Officers, company.Address,
 			company.TransferAgent, company.Auditor, company.Last10KDate,
 			company.Last10QDate, company.LastFilingDate, company.ProfileVerified,
-			company.CreatedAt, company.UpdatedAt,
-		)
-		
+			company.ReportingStatus, company.FilingDatesUncertain, missingScrapes,
+			company.CUSIP, company.CIK, company.PaidPromotion, company.MarketTierRaw, company.CreatedAt, company.UpdatedAt,
+			s.cfg.DelinquencyConfirmationScrapes,
+		).Scan(&company.ID, &company.CreatedAt, &company.FilingDatesUncertain, &inserted)
+
 		if err != nil {
-			return fmt.Errorf("failed to insert new company: %w", err)
+			return fmt.Errorf("failed to upsert company: %w", err)
 		}
-		
-		log.Printf("Inserted new company: %s", company.Ticker)
-	} else if err == nil {
-		// Update existing company
+
+		if inserted {
+			log.Printf("Inserted new company: %s", company.Ticker)
+		} else {
+			log.Printf("Updated company inserted concurrently by another job: %s", company.Ticker)
+		}
+	} else {
+		// Update existing company (ticker is set in case it changed under the same CUSIP)
 		company.ID = existingID
 		company.CreatedAt = existingUpdatedAt // Preserve original creation time
 		
@@ -248,13 +444,18 @@ func (s *Service) storeCompany(ctx context.Context, company *models.Company, scr
 				company_name = $2, market_tier = $3, quote_status = $4, trading_volume = $5,
 				website = $6, description = $7, officers = $8, address = $9,
 				transfer_agent = $10, auditor = $11, last_10k_date = $12, last_10q_date = $13,
-				last_filing_date = $14, profile_verified = $15, updated_at = $16
+				last_filing_date = $14, profile_verified = $15, reporting_status = $16,
+				filing_dates_uncertain = $17, filing_dates_missing_scrapes = $18, updated_at = $19,
+				ticker = $20, cusip = COALESCE(NULLIF($21, ''), cusip),
+				cik = COALESCE(NULLIF($22, ''), cik), paid_promotion = $23, market_tier_raw = $24
 			WHERE id = $1`,
 			company.ID, company.CompanyName, company.MarketTier, company.QuoteStatus,
 			company.TradingVolume, company.Website, company.Description,
 			company.Officers, company.Address, company.TransferAgent, company.Auditor,
 			company.Last10KDate, company.Last10QDate, company.LastFilingDate,
-			company.ProfileVerified, company.UpdatedAt,
+			company.ProfileVerified, company.ReportingStatus, company.FilingDatesUncertain,
+			missingScrapes, company.UpdatedAt, company.Ticker, company.CUSIP, company.CIK,
+			company.PaidPromotion, company.MarketTierRaw,
 		)
 		
 		if err != nil {
@@ -262,8 +463,37 @@ func (s *Service) storeCompany(ctx context.Context, company *models.Company, scr
 		}
 		
 		log.Printf("Updated existing company: %s", company.Ticker)
-	} else {
-		return fmt.Errorf("failed to check existing company: %w", err)
+	}
+
+	// Skip the snapshot when nothing changed since the latest one within the dedupe
+	// window, recording that the content was seen again instead
+	contentHash, err := snapshotHash(company)
+	if err != nil {
+		return err
+	}
+
+	if window := time.Duration(s.cfg.SnapshotDedupeWindowHours) * time.Hour; window > 0 {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE company_history SET last_seen_at = $3
+			WHERE id = (
+				SELECT id FROM company_history
+				WHERE company_id = $1
+				ORDER BY scraped_at DESC
+				LIMIT 1
+			)
+			  AND content_hash = $2
+			  AND scraped_at >= $4`,
+			company.ID, contentHash, scraped.ScrapedAt, scraped.ScrapedAt.Add(-window),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to check latest snapshot: %w", err)
+		}
+		if unchanged, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to check latest snapshot: %w", err)
+		} else if unchanged > 0 {
+			log.Printf("Skipped unchanged snapshot for %s", company.Ticker)
+			return tx.Commit()
+		}
 	}
 
 	// Store historical snapshot
@@ -277,9 +507,9 @@ func (s *Service) storeCompany(ctx context.Context, company *models.Company, scr
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO company_history (company_id, snapshot_data, scraped_at)
-		VALUES ($1, $2, $3)`,
-		company.ID, snapshotData, scraped.ScrapedAt,
+		INSERT INTO company_history (company_id, snapshot_data, scraped_at, content_hash)
+		VALUES ($1, $2, $3, $4)`,
+		company.ID, snapshotData, scraped.ScrapedAt, contentHash,
 	)
 
 	if err != nil {
@@ -289,16 +519,30 @@ func (s *Service) storeCompany(ctx context.Context, company *models.Company, scr
 	return tx.Commit()
 }
 
+// trackMissingFilingDates returns the updated count of consecutive scrapes without
+// extractable 10-K/10-Q dates and marks the company's filing dates as uncertain until
+// that count reaches the configured confirmation threshold
+func (s *Service) trackMissingFilingDates(company *models.Company, previousMissingScrapes int) int {
+	if company.Last10KDate != nil && company.Last10QDate != nil {
+		company.FilingDatesUncertain = false
+		return 0
+	}
+
+	missingScrapes := previousMissingScrapes + 1
+	company.FilingDatesUncertain = missingScrapes < s.cfg.DelinquencyConfirmationScrapes
+	return missingScrapes
+}
+
 // createScrapeJob creates a new scrape job record
 func (s *Service) createScrapeJob(ctx context.Context, job *models.ScrapeJob) error {
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO scrape_jobs (
 			id, status, total_tickers, processed_tickers, failed_tickers,
-			started_by, started_at, completed_at, error_message
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			started_by, started_at, completed_at, error_message, priority
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 		job.ID, job.Status, job.TotalTickers, job.ProcessedTickers,
 		job.FailedTickers, job.StartedBy, job.StartedAt, job.CompletedAt,
-		job.ErrorMessage,
+		job.ErrorMessage, job.Priority,
 	)
 	return err
 }
@@ -308,44 +552,51 @@ func (s *Service) updateScrapeJob(ctx context.Context, job *models.ScrapeJob) er
 	_, err := s.db.ExecContext(ctx, `
 		UPDATE scrape_jobs SET
 			status = $2, processed_tickers = $3, failed_tickers = $4,
-			completed_at = $5, error_message = $6
+			completed_at = $5, error_message = $6, oxylabs_requests = $7
 		WHERE id = $1`,
 		job.ID, job.Status, job.ProcessedTickers, job.FailedTickers,
-		job.CompletedAt, job.ErrorMessage,
+		job.CompletedAt, job.ErrorMessage, job.OxyLabsRequests,
 	)
 	return err
 }
 
-// GetUserJobs retrieves all scrape jobs for a specific user
-func (s *Service) GetUserJobs(ctx context.Context, userID uuid.UUID) ([]*models.ScrapeJob, error) {
+// GetUserJobs retrieves one page of a user's scrape jobs, newest first, with the
+// total number of jobs they have started
+func (s *Service) GetUserJobs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.ScrapeJob, int, error) {
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM scrape_jobs WHERE started_by = $1", userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count scrape jobs: %w", err)
+	}
+
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT id, status, total_tickers, processed_tickers, failed_tickers,
-			   started_by, started_at, completed_at, error_message
+			   started_by, started_at, completed_at, error_message, priority, oxylabs_requests
 		FROM scrape_jobs 
 		WHERE started_by = $1
-		ORDER BY started_at DESC`,
-		userID,
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3`,
+		userID, limit, offset,
 	)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var jobs []*models.ScrapeJob
+	jobs := []*models.ScrapeJob{}
 	for rows.Next() {
 		job := &models.ScrapeJob{}
 		err := rows.Scan(
 			&job.ID, &job.Status, &job.TotalTickers, &job.ProcessedTickers,
 			&job.FailedTickers, &job.StartedBy, &job.StartedAt,
-			&job.CompletedAt, &job.ErrorMessage,
+			&job.CompletedAt, &job.ErrorMessage, &job.Priority, &job.OxyLabsRequests,
 		)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		jobs = append(jobs, job)
 	}
 
-	return jobs, nil
+	return jobs, total, rows.Err()
 }
 
 // GetJob retrieves a scrape job by ID (alias for GetScrapeJob for API compatibility)
@@ -358,13 +609,13 @@ func (s *Service) GetScrapeJob(ctx context.Context, jobID uuid.UUID) (*models.Sc
 	job := &models.ScrapeJob{}
 	err := s.db.QueryRowContext(ctx, `
 		SELECT id, status, total_tickers, processed_tickers, failed_tickers,
-			   started_by, started_at, completed_at, error_message
+			   started_by, started_at, completed_at, error_message, priority, oxylabs_requests
 		FROM scrape_jobs WHERE id = $1`,
 		jobID,
 	).Scan(
 		&job.ID, &job.Status, &job.TotalTickers, &job.ProcessedTickers,
 		&job.FailedTickers, &job.StartedBy, &job.StartedAt,
-		&job.CompletedAt, &job.ErrorMessage,
+		&job.CompletedAt, &job.ErrorMessage, &job.Priority, &job.OxyLabsRequests,
 	)
 
 	if err != nil {
@@ -378,7 +629,7 @@ func (s *Service) GetScrapeJob(ctx context.Context, jobID uuid.UUID) (*models.Sc
 func (s *Service) GetRecentScrapeJobs(ctx context.Context, limit int) ([]*models.ScrapeJob, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT id, status, total_tickers, processed_tickers, failed_tickers,
-			   started_by, started_at, completed_at, error_message
+			   started_by, started_at, completed_at, error_message, priority, oxylabs_requests
 		FROM scrape_jobs 
 		ORDER BY started_at DESC 
 		LIMIT $1`,
@@ -395,7 +646,7 @@ func (s *Service) GetRecentScrapeJobs(ctx context.Context, limit int) ([]*models
 		err := rows.Scan(
 			&job.ID, &job.Status, &job.TotalTickers, &job.ProcessedTickers,
 			&job.FailedTickers, &job.StartedBy, &job.StartedAt,
-			&job.CompletedAt, &job.ErrorMessage,
+			&job.CompletedAt, &job.ErrorMessage, &job.Priority, &job.OxyLabsRequests,
 		)
 		if err != nil {
 			return nil, err
@@ -406,6 +657,143 @@ func (s *Service) GetRecentScrapeJobs(ctx context.Context, limit int) ([]*models
 	return jobs, nil
 }
 
+// Sources of OxyLabs requests made outside scrape jobs
+const (
+	OxyLabsUsageRescrape = "rescrape"  // POST /companies/:ticker/rescrape
+	OxyLabsUsageParseURL = "parse_url" // POST /admin/parse-url
+	OxyLabsUsageCLI      = "cli"       // cmd/test-scraper
+)
+
+// recordOxyLabsUsage logs requests made outside a scrape job for credit reporting.
+// Failures are logged rather than returned so they never fail the scrape itself.
+func (s *Service) recordOxyLabsUsage(ctx context.Context, source, target string, requests int) {
+	if requests <= 0 {
+		return
+	}
+	if len(target) > 500 {
+		target = target[:500]
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO oxylabs_usage (source, target, requests) VALUES ($1, $2, $3)`,
+		source, target, requests,
+	); err != nil {
+		log.Printf("Warning: failed to record %d OxyLabs requests for %s %s: %v", requests, source, target, err)
+	}
+}
+
+// DailyCreditUsage is the OxyLabs usage of one day: jobs started that day plus
+// requests made outside jobs
+type DailyCreditUsage struct {
+	Date          string `json:"date"`
+	Jobs          int    `json:"jobs"`
+	Tickers       int    `json:"tickers"`
+	Requests      int64  `json:"requests"`
+	AdHocRequests int64  `json:"ad_hoc_requests"` // Part of Requests from rescrapes, parse-URL previews and CLI scrapes
+}
+
+// CreditUsage totals the OxyLabs requests consumed in a period
+type CreditUsage struct {
+	Jobs          int                `json:"jobs"`
+	Tickers       int                `json:"tickers"`
+	Requests      int64              `json:"requests"`
+	AdHocRequests int64              `json:"ad_hoc_requests"`
+	Daily         []DailyCreditUsage `json:"daily"`
+}
+
+// GetCreditUsage aggregates OxyLabs requests by day, for scrape jobs started and
+// ad hoc requests recorded in [from, to). Nil bounds are open.
+func (s *Service) GetCreditUsage(ctx context.Context, from, to *time.Time) (*CreditUsage, error) {
+	var args []interface{}
+	var jobConditions, usageConditions []string
+
+	if from != nil {
+		args = append(args, *from)
+		jobConditions = append(jobConditions, fmt.Sprintf("started_at >= $%d", len(args)))
+		usageConditions = append(usageConditions, fmt.Sprintf("recorded_at >= $%d", len(args)))
+	}
+
+	if to != nil {
+		args = append(args, *to)
+		jobConditions = append(jobConditions, fmt.Sprintf("started_at < $%d", len(args)))
+		usageConditions = append(usageConditions, fmt.Sprintf("recorded_at < $%d", len(args)))
+	}
+
+	where := func(conditions []string) string {
+		if len(conditions) == 0 {
+			return ""
+		}
+		return " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), COALESCE(SUM(jobs), 0), COALESCE(SUM(tickers), 0),
+		       COALESCE(SUM(requests), 0), COALESCE(SUM(ad_hoc_requests), 0)
+		FROM (
+			SELECT date_trunc('day', started_at) AS day, 1 AS jobs, total_tickers AS tickers,
+			       oxylabs_requests AS requests, 0 AS ad_hoc_requests
+			FROM scrape_jobs`+where(jobConditions)+`
+			UNION ALL
+			SELECT date_trunc('day', recorded_at), 0, 0, requests, requests
+			FROM oxylabs_usage`+where(usageConditions)+`
+		) usage
+		GROUP BY day
+		ORDER BY day`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query credit usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := &CreditUsage{Daily: []DailyCreditUsage{}}
+	for rows.Next() {
+		var day DailyCreditUsage
+		if err := rows.Scan(&day.Date, &day.Jobs, &day.Tickers, &day.Requests, &day.AdHocRequests); err != nil {
+			return nil, fmt.Errorf("failed to scan credit usage: %w", err)
+		}
+		usage.Jobs += day.Jobs
+		usage.Tickers += day.Tickers
+		usage.Requests += day.Requests
+		usage.AdHocRequests += day.AdHocRequests
+		usage.Daily = append(usage.Daily, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate credit usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// ParsePreview is the parser output for a single page, used to diagnose extraction issues
+type ParsePreview struct {
+	URL       string                 `json:"url"`
+	PageType  string                 `json:"page_type"`
+	Data      map[string]interface{} `json:"data"`
+	FetchedAt time.Time              `json:"fetched_at"`
+}
+
+// ParseURL fetches an OTC Markets page through OxyLabs and returns what the
+// parser extracts from it, without storing anything
+func (s *Service) ParseURL(ctx context.Context, rawURL, pageType string) (*ParsePreview, error) {
+	resolved, err := ResolvePageType(rawURL, pageType)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.scraper.ParsePage(ctx, rawURL, resolved)
+	s.recordOxyLabsUsage(ctx, OxyLabsUsageParseURL, rawURL, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParsePreview{
+		URL:       rawURL,
+		PageType:  resolved,
+		Data:      data,
+		FetchedAt: time.Now(),
+	}, nil
+}
+
 // GetScraperHealthStatus returns detailed health status of the scraper
 func (s *Service) GetScraperHealthStatus() HealthStatus {
 	return s.scraper.GetHealthStatus()
@@ -449,6 +837,7 @@ func (s *Service) GetCompanies(ctx context.Context, page, limit int, search, mar
 	baseQuery := `SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
 	              website, description, officers, address, transfer_agent, auditor,
 	              last_10k_date, last_10q_date, last_filing_date, profile_verified,
+	              reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 	              created_at, updated_at FROM companies`
 	
 	countQuery := `SELECT COUNT(*) FROM companies`
@@ -464,6 +853,10 @@ func (s *Service) GetCompanies(ctx context.Context, page, limit int, search, mar
 	}
 	
 	if marketTier != "" {
+		// Accept raw tier text such as "OTC Pink" as well as canonical values
+		if canonical := models.NormalizeMarketTier(marketTier); canonical != "" {
+			marketTier = canonical
+		}
 		conditions = append(conditions, fmt.Sprintf("market_tier = $%d", argIndex))
 		args = append(args, marketTier)
 		argIndex++
@@ -500,6 +893,7 @@ func (s *Service) GetCompanies(ctx context.Context, page, limit int, search, mar
 			&company.Description, &company.Officers, &company.Address,
 			&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 			&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+			&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 			&company.CreatedAt, &company.UpdatedAt,
 		)
 		if err != nil {
@@ -516,6 +910,7 @@ func (s *Service) GetCompanyByTicker(ctx context.Context, ticker string) (*model
 	query := `SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
 	          website, description, officers, address, transfer_agent, auditor,
 	          last_10k_date, last_10q_date, last_filing_date, profile_verified,
+	          reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
 	          created_at, updated_at FROM companies WHERE ticker = $1`
 	
 	var company models.Company
@@ -525,6 +920,7 @@ func (s *Service) GetCompanyByTicker(ctx context.Context, ticker string) (*model
 		&company.Description, &company.Officers, &company.Address,
 		&company.TransferAgent, &company.Auditor, &company.Last10KDate,
 		&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+		&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
 		&company.CreatedAt, &company.UpdatedAt,
 	)
 	
@@ -539,6 +935,276 @@ func (s *Service) GetCompanyByTicker(ctx context.Context, ticker string) (*model
 	return &company, nil
 }
 
+// ClaimRescrape atomically claims an on-demand rescrape of the ticker. It returns 0
+// once the claim is recorded, or how long until the ticker may be rescraped when
+// another request claimed it within the cooldown. The claim lives in
+// rescrape_requests so it holds across server instances, and it counts whether or
+// not the scrape that follows succeeds.
+func (s *Service) ClaimRescrape(ctx context.Context, ticker string) (time.Duration, error) {
+	cooldownMinutes := s.cfg.RescrapeCooldownMinutes
+	if cooldownMinutes <= 0 {
+		return 0, nil
+	}
+	ticker = strings.ToUpper(ticker)
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO rescrape_requests (ticker, last_requested_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (ticker) DO UPDATE SET last_requested_at = NOW()
+		WHERE rescrape_requests.last_requested_at <= NOW() - $2::int * INTERVAL '1 minute'`,
+		ticker, cooldownMinutes,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim rescrape: %w", err)
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim rescrape: %w", err)
+	}
+	if claimed > 0 {
+		return 0, nil
+	}
+
+	var remainingSeconds float64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM last_requested_at + $2::int * INTERVAL '1 minute' - NOW())
+		FROM rescrape_requests
+		WHERE ticker = $1`,
+		ticker, cooldownMinutes,
+	).Scan(&remainingSeconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rescrape cooldown: %w", err)
+	}
+
+	// The claim raced with the cooldown expiring; report the shortest wait
+	if remainingSeconds <= 0 {
+		return time.Second, nil
+	}
+	return time.Duration(remainingSeconds * float64(time.Second)), nil
+}
+
+// GetCompaniesByTickers retrieves the companies for a set of tickers in one query and
+// reports which of the requested tickers were not found
+func (s *Service) GetCompaniesByTickers(ctx context.Context, tickers []string) ([]models.Company, []string, error) {
+	if len(tickers) == 0 {
+		return []models.Company{}, []string{}, nil
+	}
+
+	placeholders := make([]string, len(tickers))
+	args := make([]interface{}, len(tickers))
+	for i, ticker := range tickers {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = strings.ToUpper(ticker)
+	}
+
+	query := `SELECT id, ticker, company_name, market_tier, quote_status, trading_volume,
+	          website, description, officers, address, transfer_agent, auditor,
+	          last_10k_date, last_10q_date, last_filing_date, profile_verified,
+	          reporting_status, filing_dates_uncertain, cusip, cik, paid_promotion, market_tier_raw,
+	          created_at, updated_at FROM companies
+	          WHERE ticker IN (` + strings.Join(placeholders, ",") + `) ORDER BY ticker`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []models.Company{}
+	found := make(map[string]bool, len(tickers))
+	for rows.Next() {
+		var company models.Company
+		err := rows.Scan(
+			&company.ID, &company.Ticker, &company.CompanyName, &company.MarketTier,
+			&company.QuoteStatus, &company.TradingVolume, &company.Website,
+			&company.Description, &company.Officers, &company.Address,
+			&company.TransferAgent, &company.Auditor, &company.Last10KDate,
+			&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+			&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
+			&company.CreatedAt, &company.UpdatedAt,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+		found[company.Ticker] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate companies: %w", err)
+	}
+
+	notFound := []string{}
+	for _, ticker := range args {
+		if !found[ticker.(string)] {
+			notFound = append(notFound, ticker.(string))
+		}
+	}
+
+	return companies, notFound, nil
+}
+
+// ScoredCompany is a company together with its best stored score
+type ScoredCompany struct {
+	models.Company
+	Score          int       `json:"score"`
+	ScoringModelID string    `json:"scoring_model_id"`
+	ModelName      string    `json:"model_name"`
+	ScoredAt       time.Time `json:"scored_at"`
+}
+
+// GetCompaniesByScore returns companies whose best score falls within [minScore, maxScore],
+// highest first. With a modelID only that model's scores count; otherwise each company's
+// best score across active models is used. Nil bounds are open.
+func (s *Service) GetCompaniesByScore(ctx context.Context, minScore, maxScore *int, modelID string, page, limit int) ([]ScoredCompany, int, error) {
+	offset := (page - 1) * limit
+
+	var scoreConditions []string
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if modelID != "" {
+		scoreConditions = append(scoreConditions, fmt.Sprintf("cs.scoring_model_id = $%d", argIndex))
+		args = append(args, modelID)
+		argIndex++
+	} else {
+		scoreConditions = append(scoreConditions, "sm.is_active = true")
+	}
+
+	if minScore != nil {
+		conditions = append(conditions, fmt.Sprintf("b.score >= $%d", argIndex))
+		args = append(args, *minScore)
+		argIndex++
+	}
+
+	if maxScore != nil {
+		conditions = append(conditions, fmt.Sprintf("b.score <= $%d", argIndex))
+		args = append(args, *maxScore)
+		argIndex++
+	}
+
+	// One row per company: its highest score, most recent on ties
+	bestScores := `WITH best AS (
+		SELECT DISTINCT ON (cs.company_id)
+			cs.company_id, cs.scoring_model_id, sm.name AS model_name, cs.score, cs.scored_at
+		FROM company_scores cs
+		JOIN scoring_models sm ON sm.id = cs.scoring_model_id
+		WHERE ` + strings.Join(scoreConditions, " AND ") + `
+		ORDER BY cs.company_id, cs.score DESC, cs.scored_at DESC
+	)`
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := s.db.QueryRowContext(ctx, bestScores+" SELECT COUNT(*) FROM best b"+whereClause, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get company count: %w", err)
+	}
+
+	query := bestScores + `
+		SELECT c.id, c.ticker, c.company_name, c.market_tier, c.quote_status, c.trading_volume,
+		       c.website, c.description, c.officers, c.address, c.transfer_agent, c.auditor,
+		       c.last_10k_date, c.last_10q_date, c.last_filing_date, c.profile_verified,
+		       c.reporting_status, c.filing_dates_uncertain, c.cusip, c.cik, c.paid_promotion, c.market_tier_raw,
+		       c.created_at, c.updated_at,
+		       b.score, b.scoring_model_id, b.model_name, b.scored_at
+		FROM best b
+		JOIN companies c ON c.id = b.company_id` + whereClause +
+		fmt.Sprintf(" ORDER BY b.score DESC, c.ticker ASC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query companies by score: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []ScoredCompany{}
+	for rows.Next() {
+		var company ScoredCompany
+		err := rows.Scan(
+			&company.ID, &company.Ticker, &company.CompanyName, &company.MarketTier,
+			&company.QuoteStatus, &company.TradingVolume, &company.Website,
+			&company.Description, &company.Officers, &company.Address,
+			&company.TransferAgent, &company.Auditor, &company.Last10KDate,
+			&company.Last10QDate, &company.LastFilingDate, &company.ProfileVerified,
+			&company.ReportingStatus, &company.FilingDatesUncertain, &company.CUSIP, &company.CIK, &company.PaidPromotion, &company.MarketTierRaw,
+			&company.CreatedAt, &company.UpdatedAt,
+			&company.Score, &company.ScoringModelID, &company.ModelName, &company.ScoredAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate companies: %w", err)
+	}
+
+	return companies, total, nil
+}
+
+// FacetValue is a distinct column value and how many companies have it
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// CompanyFacets lists the filterable values present in the companies table
+type CompanyFacets struct {
+	MarketTiers   []FacetValue `json:"market_tiers"`
+	QuoteStatuses []FacetValue `json:"quote_statuses"`
+}
+
+// GetCompanyFacets returns the distinct market tiers and quote statuses stored,
+// most common first, so filter UIs reflect live data
+func (s *Service) GetCompanyFacets(ctx context.Context) (*CompanyFacets, error) {
+	marketTiers, err := s.getFacetValues(ctx, "market_tier")
+	if err != nil {
+		return nil, err
+	}
+
+	quoteStatuses, err := s.getFacetValues(ctx, "quote_status")
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompanyFacets{MarketTiers: marketTiers, QuoteStatuses: quoteStatuses}, nil
+}
+
+// getFacetValues counts companies per non-empty value of column. column must be a
+// trusted identifier, never client input.
+func (s *Service) getFacetValues(ctx context.Context, column string) ([]FacetValue, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %[1]s, COUNT(*)
+		FROM companies
+		WHERE %[1]s IS NOT NULL AND %[1]s <> ''
+		GROUP BY %[1]s
+		ORDER BY COUNT(*) DESC, %[1]s ASC`, column))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s facets: %w", column, err)
+	}
+	defer rows.Close()
+
+	values := []FacetValue{}
+	for rows.Next() {
+		var value FacetValue
+		if err := rows.Scan(&value.Value, &value.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s facet: %w", column, err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate %s facets: %w", column, err)
+	}
+
+	return values, nil
+}
+
 // scoreCompanyAfterScrape automatically scores a company after scraping using all active ICP models
 func (s *Service) scoreCompanyAfterScrape(ctx context.Context, companyID string) error {
 	log.Printf("Starting automatic scoring for company ID: %s", companyID)
@@ -548,9 +1214,14 @@ func (s *Service) scoreCompanyAfterScrape(ctx context.Context, companyID string)
 	defer cancel()
 	
 	// Score the company using the scoring service
-	if err := s.scoringService.ScoreCompany(companyID); err != nil {
+	result, err := s.scoringService.ScoreCompany(companyID)
+	if err != nil {
 		return fmt.Errorf("failed to score company %s: %w", companyID, err)
 	}
+	if result.Partial() {
+		log.Printf("Company ID %s partially %s", companyID, result.Summary())
+		return nil
+	}
 	
 	log.Printf("Successfully scored company ID: %s", companyID)
 	return nil
@@ -604,8 +1275,10 @@ func (s *Service) ScoreExistingCompanies(ctx context.Context, modelID string) er
 					}
 				} else {
 					// Score with all active models
-					if err := s.scoringService.ScoreCompany(companyID); err != nil {
+					if result, err := s.scoringService.ScoreCompany(companyID); err != nil {
 						log.Printf("Failed to score company %s: %v", companyID, err)
+					} else if result.Partial() {
+						log.Printf("Company %s partially %s", companyID, result.Summary())
 					}
 				}
 			}
@@ -2,17 +2,75 @@ package scraper
 
 import (
 	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 )
 
 // Transformer converts scraped data to company models
-type Transformer struct{}
+type Transformer struct {
+	tierAliases []marketTierAlias
+}
+
+// marketTierAlias maps raw tier text matching pattern to a canonical tier
+type marketTierAlias struct {
+	pattern *regexp.Regexp
+	tier    string
+}
 
 // NewTransformer creates a new transformer instance
 func NewTransformer() *Transformer {
-	return &Transformer{}
+	return NewTransformerWithTierAliases(nil)
+}
+
+// NewTransformerWithTierAliases creates a transformer whose market tier normalization
+// checks the given raw-text-to-tier aliases before the defaults. Aliases match
+// case-insensitively as substrings; ones naming an unknown tier are ignored.
+func NewTransformerWithTierAliases(aliases map[string]string) *Transformer {
+	var custom []marketTierAlias
+	for raw, tier := range aliases {
+		canonical := models.CanonicalMarketTier(tier)
+		if canonical == "" {
+			log.Printf("Ignoring market tier alias %q: unknown tier %q", raw, tier)
+			continue
+		}
+		custom = append(custom, marketTierAlias{
+			pattern: regexp.MustCompile(`(?i)` + regexp.QuoteMeta(strings.TrimSpace(raw))),
+			tier:    canonical,
+		})
+	}
+
+	// Longer aliases first so the most specific configured text wins
+	sort.Slice(custom, func(i, j int) bool {
+		return len(custom[i].pattern.String()) > len(custom[j].pattern.String())
+	})
+
+	return &Transformer{tierAliases: custom}
+}
+
+// NormalizeMarketTier maps raw tier text (e.g. "OTC Pink", "Pink Current Information")
+// to its canonical tier, or returns "" when the text isn't recognized. Configured
+// aliases are checked before models.NormalizeMarketTier's defaults.
+func (t *Transformer) NormalizeMarketTier(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	if canonical := models.CanonicalMarketTier(raw); canonical != "" {
+		return canonical
+	}
+
+	for _, alias := range t.tierAliases {
+		if alias.pattern.MatchString(raw) {
+			return alias.tier
+		}
+	}
+	return models.NormalizeMarketTier(raw)
 }
 
 // TransformToCompany converts ScrapedData to a Company model
@@ -50,7 +108,8 @@ func (t *Transformer) TransformToCompany(scraped *models.ScrapedData) (*models.C
 	}
 
 	if tier, ok := allData["market_tier"].(string); ok {
-		company.MarketTier = tier
+		company.MarketTierRaw = tier
+		company.MarketTier = t.NormalizeMarketTier(tier)
 	}
 
 	if status, ok := allData["quote_status"].(string); ok {
@@ -101,6 +160,18 @@ func (t *Transformer) TransformToCompany(scraped *models.ScrapedData) (*models.C
 		company.ProfileVerified = verified
 	}
 
+	if cusip, ok := allData["cusip"].(string); ok {
+		company.CUSIP = cusip
+	}
+
+	if status, ok := allData["reporting_status"].(string); ok {
+		company.ReportingStatus = status
+	}
+
+	if promoted, ok := allData["paid_promotion"].(bool); ok {
+		company.PaidPromotion = promoted
+	}
+
 	return company, nil
 }
 
@@ -116,7 +187,9 @@ func (t *Transformer) ValidateCompanyData(company *models.Company) []string {
 		errors = append(errors, "company name is missing")
 	}
 
-	if company.MarketTier == "" {
+	if company.MarketTier == "" && company.MarketTierRaw != "" {
+		errors = append(errors, fmt.Sprintf("market tier %q is not recognized", company.MarketTierRaw))
+	} else if company.MarketTier == "" {
 		errors = append(errors, "market tier is missing")
 	}
 
@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+)
+
+func TestTransformer_NormalizeMarketTier(t *testing.T) {
+	transformer := NewTransformer()
+
+	testCases := []struct {
+		raw      string
+		expected string
+	}{
+		{"Expert Market", models.MarketTierExpertMarket},
+		{"Grey Market", models.MarketTierGreyMarket},
+		{"Gray Market", models.MarketTierGreyMarket},
+		{"Pink No Information", models.MarketTierExpertMarket},
+		{"Pink Limited", models.MarketTierPinkLimited},
+		{"Pink Limited Information", models.MarketTierPinkLimited},
+		{"Pink Current", models.MarketTierPinkCurrent},
+		{"OTC Pink", models.MarketTierPinkCurrent},
+		{"Pink Market", models.MarketTierPinkCurrent},
+		{"OTCQB Venture Market", models.MarketTierOTCQB},
+		{"OTCQX Best Market", models.MarketTierOTCQX},
+		{"pinklimited", models.MarketTierPinkLimited},
+		{"NASDAQ", ""},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			if got := transformer.NormalizeMarketTier(tc.raw); got != tc.expected {
+				t.Errorf("NormalizeMarketTier(%q) = %q, expected %q", tc.raw, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTransformer_ConfiguredTierAliases(t *testing.T) {
+	transformer := NewTransformerWithTierAliases(map[string]string{
+		"pink sheets": "pinkcurrent",
+		"NASDAQ":      "NotATier",
+	})
+
+	if got := transformer.NormalizeMarketTier("Pink Sheets"); got != models.MarketTierPinkCurrent {
+		t.Errorf("Expected configured alias to map to %s, got %q", models.MarketTierPinkCurrent, got)
+	}
+	if got := transformer.NormalizeMarketTier("NASDAQ"); got != "" {
+		t.Errorf("Expected alias with unknown tier to be ignored, got %q", got)
+	}
+}
+
+func TestTransformer_KeepsRawMarketTier(t *testing.T) {
+	transformer := NewTransformer()
+
+	company, err := transformer.TransformToCompany(&models.ScrapedData{
+		Ticker:   "ABCD",
+		Overview: map[string]interface{}{"market_tier": "Pink Limited Information"},
+	})
+	if err != nil {
+		t.Fatalf("TransformToCompany failed: %v", err)
+	}
+
+	if company.MarketTier != models.MarketTierPinkLimited {
+		t.Errorf("Expected market tier %s, got %q", models.MarketTierPinkLimited, company.MarketTier)
+	}
+	if company.MarketTierRaw != "Pink Limited Information" {
+		t.Errorf("Expected raw market tier to be kept, got %q", company.MarketTierRaw)
+	}
+}
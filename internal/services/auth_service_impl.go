@@ -22,7 +22,7 @@ type authServiceImpl struct {
 func newAuthService(repos *repository.Repositories, cfg *config.Config) AuthService {
 	return &authServiceImpl{
 		repos:      repos,
-		jwtService: auth.NewJWTService(cfg.JWTSecret),
+		jwtService: auth.NewJWTServiceWithKeys(auth.KeySetFromConfig(cfg)),
 		cfg:        cfg,
 	}
 }
@@ -139,6 +139,7 @@ func (s *companyServiceImpl) convertFromModelsCompany(company *models.Company) *
 		Ticker:          company.Ticker,
 		CompanyName:     company.CompanyName,
 		MarketTier:      company.MarketTier,
+		MarketTierRaw:   company.MarketTierRaw,
 		QuoteStatus:     company.QuoteStatus,
 		TradingVolume:   company.TradingVolume,
 		Website:         company.Website,
@@ -151,6 +152,8 @@ func (s *companyServiceImpl) convertFromModelsCompany(company *models.Company) *
 		Last10QDate:     company.Last10QDate,
 		LastFilingDate:  company.LastFilingDate,
 		ProfileVerified: company.ProfileVerified,
+		ReportingStatus: company.ReportingStatus,
+		PaidPromotion:   company.PaidPromotion,
 		CreatedAt:       company.CreatedAt,
 		UpdatedAt:       company.UpdatedAt,
 	}
@@ -168,6 +171,7 @@ func (s *companyServiceImpl) convertToModelsCompany(company *repository.Company)
 		Ticker:          company.Ticker,
 		CompanyName:     company.CompanyName,
 		MarketTier:      company.MarketTier,
+		MarketTierRaw:   company.MarketTierRaw,
 		QuoteStatus:     company.QuoteStatus,
 		TradingVolume:   company.TradingVolume,
 		Website:         company.Website,
@@ -180,6 +184,8 @@ func (s *companyServiceImpl) convertToModelsCompany(company *repository.Company)
 		Last10QDate:     company.Last10QDate,
 		LastFilingDate:  company.LastFilingDate,
 		ProfileVerified: company.ProfileVerified,
+		ReportingStatus: company.ReportingStatus,
+		PaidPromotion:   company.PaidPromotion,
 		CreatedAt:       company.CreatedAt,
 		UpdatedAt:       company.UpdatedAt,
 	}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"encoding/csv"
 	"encoding/json"
@@ -8,24 +9,65 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/lib/pq"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
 )
 
+// defaultRequiredMinScore is the IncludeRequiredOnly threshold for models whose
+// rules don't record a minimum_score
+const defaultRequiredMinScore = 3
+
 // LeadExportService handles filtering and exporting qualified companies
 type LeadExportService struct {
-	db             *sql.DB
-	scoringService ScoringService
+	db               *sql.DB
+	scoringService   ScoringService
+	requiredMinScore int
 }
 
 // NewLeadExportService creates a new lead export service
 func NewLeadExportService(db *sql.DB, scoringService ScoringService) *LeadExportService {
+	return NewLeadExportServiceWithMinScore(db, scoringService, defaultRequiredMinScore)
+}
+
+// NewLeadExportServiceWithMinScore creates a lead export service whose
+// IncludeRequiredOnly filter falls back to requiredMinScore for models
+// without a stored minimum_score
+func NewLeadExportServiceWithMinScore(db *sql.DB, scoringService ScoringService, requiredMinScore int) *LeadExportService {
+	if requiredMinScore <= 0 {
+		requiredMinScore = defaultRequiredMinScore
+	}
+
 	return &LeadExportService{
-		db:             db,
-		scoringService: scoringService,
+		db:               db,
+		scoringService:   scoringService,
+		requiredMinScore: requiredMinScore,
 	}
 }
 
+// insightsVersion identifies the current addBusinessInsights logic. Bump it when
+// the insight rules change so stored snapshots from older logic are ignored.
+const insightsVersion = 4
+
+// LeadInsights is the persisted snapshot of a lead's business insights
+type LeadInsights struct {
+	RiskIndicators      []string `json:"risk_indicators"`
+	Opportunities       []string `json:"opportunities"`
+	RecommendedServices []string `json:"recommended_services"`
+}
+
+// InsightsRefreshResult summarizes a bulk insights refresh
+type InsightsRefreshResult struct {
+	Version     int           `json:"version"`
+	Refreshed   int           `json:"refreshed"`
+	Duration    time.Duration `json:"duration"`
+	CompletedAt time.Time     `json:"completed_at"`
+}
+
 // LeadFilter contains filtering criteria for qualified companies
 type LeadFilter struct {
 	ModelIDs             []string  `json:"model_ids"`              // ICP models to include
@@ -40,24 +82,50 @@ type LeadFilter struct {
 	HasWebsite           *bool     `json:"has_website"`            // Filter by website presence
 	HasTransferAgent     *bool     `json:"has_transfer_agent"`     // Filter by transfer agent presence
 	HasAuditor           *bool     `json:"has_auditor"`            // Filter by auditor presence
+	HasContactInfo       *bool     `json:"has_contact_info"`       // Filter by an enriched contact email or phone
+	MinDaysSinceTierChange *int    `json:"min_days_since_tier_change"` // Tier changed at least this many days ago
+	MaxDaysSinceTierChange *int    `json:"max_days_since_tier_change"` // Tier changed at most this many days ago
+	QualifiedAfter       *time.Time `json:"qualified_after"`       // Became qualified at or after this time
+	QualifiedBefore      *time.Time `json:"qualified_before"`      // Became qualified before this time
 	IncludeRequiredOnly  bool      `json:"include_required_only"`  // Only companies meeting requirements
 	ExcludeFields        []string  `json:"exclude_fields"`         // Fields to exclude from export
+	UseStoredInsights    bool      `json:"use_stored_insights"`    // Use persisted insights snapshots when current
+	RiskIndicators       []string  `json:"risk_indicators"`        // Triggered scoring rules (e.g. delinquent_10k); any match
+	DedupeByCompany      bool      `json:"dedupe_by_company"`      // One row per company, keeping its best-scoring model
 	Limit                *int      `json:"limit"`                  // Limit number of results
+	Offset               *int      `json:"offset"`                 // Skip this many results, for paging with Limit
 }
 
 // ExportFormat specifies the format for exporting leads
 type ExportFormat string
 
 const (
-	FormatJSON ExportFormat = "json"
-	FormatCSV  ExportFormat = "csv"
+	FormatJSON  ExportFormat = "json"
+	FormatCSV   ExportFormat = "csv"
+	FormatVCard ExportFormat = "vcard"
 )
 
+// ParseExportFormat resolves a client-supplied format name, defaulting to JSON
+// when none is given. Unknown names are an error rather than a silent fallback.
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "vcard", "vcf":
+		return FormatVCard, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q; supported formats: json, csv, vcard", name)
+	}
+}
+
 // LeadExportOptions contains options for exporting leads
 type LeadExportOptions struct {
 	Format       ExportFormat `json:"format"`
 	IncludeScoreBreakdown bool  `json:"include_score_breakdown"`
 	IncludeMetadata      bool  `json:"include_metadata"`
+	DedupeByCompany      bool  `json:"dedupe_by_company"` // One row per company, keeping its best-scoring model
 }
 
 // QualifiedLead represents a company qualified for outreach
@@ -65,6 +133,7 @@ type QualifiedLead struct {
 	// Company Information
 	ID              string    `json:"id" csv:"id"`
 	Ticker          string    `json:"ticker" csv:"ticker"`
+	CUSIP           string    `json:"cusip" csv:"cusip"`
 	CompanyName     string    `json:"company_name" csv:"company_name"`
 	MarketTier      string    `json:"market_tier" csv:"market_tier"`
 	QuoteStatus     string    `json:"quote_status" csv:"quote_status"`
@@ -77,6 +146,10 @@ type QualifiedLead struct {
 	Address         *string   `json:"address" csv:"address"`
 	TransferAgent   *string   `json:"transfer_agent" csv:"transfer_agent"`
 	Auditor         *string   `json:"auditor" csv:"auditor"`
+	ContactName     *string   `json:"contact_name,omitempty" csv:"-"`  // Most recently enriched contact
+	ContactTitle    *string   `json:"contact_title,omitempty" csv:"-"`
+	ContactEmail    *string   `json:"contact_email,omitempty" csv:"-"`
+	ContactPhone    *string   `json:"contact_phone,omitempty" csv:"-"`
 	
 	// Filing Information
 	Last10KDate     *time.Time `json:"last_10k_date" csv:"last_10k_date"`
@@ -88,15 +161,21 @@ type QualifiedLead struct {
 	ModelID         string                     `json:"model_id" csv:"model_id"`
 	ModelName       string                     `json:"model_name" csv:"model_name"`
 	Score           int                        `json:"score" csv:"score"`
+	ScorePercent    float64                    `json:"score_percent" csv:"score_percent"`
 	Qualified       bool                       `json:"qualified" csv:"qualified"`
 	RequirementsMet bool                       `json:"requirements_met" csv:"requirements_met"`
 	ScoreBreakdown  map[string]scoring.ScoreDetail `json:"score_breakdown,omitempty" csv:"-"`
 	ScoredAt        time.Time                  `json:"scored_at" csv:"scored_at"`
+	QualifiedSince  *time.Time                 `json:"qualified_since" csv:"qualified_since"` // When the company last became qualified; nil if not qualified or qualified before tracking began
+	QualifyingModels []string                  `json:"qualifying_models,omitempty" csv:"qualifying_models"` // Set when deduplicated by company
 	
 	// Business Insights
 	RiskIndicators  []string  `json:"risk_indicators" csv:"risk_indicators"`
 	Opportunities   []string  `json:"opportunities" csv:"opportunities"`
 	RecommendedServices []string `json:"recommended_services" csv:"recommended_services"`
+	InsightsRefreshedAt *time.Time `json:"insights_refreshed_at,omitempty" csv:"-"` // Set when served from a stored snapshot
+
+	storedInsights *LeadInsights // Current persisted snapshot, if any
 }
 
 // GetQualifiedLeads retrieves companies that match the filtering criteria
@@ -116,8 +195,15 @@ func (s *LeadExportService) GetQualifiedLeads(filter LeadFilter) ([]QualifiedLea
 			return nil, fmt.Errorf("failed to scan qualified lead: %w", err)
 		}
 		
-		// Add business insights
-		s.addBusinessInsights(&lead)
+		// Add business insights, preferring a current stored snapshot when requested
+		if filter.UseStoredInsights && lead.storedInsights != nil {
+			lead.RiskIndicators = lead.storedInsights.RiskIndicators
+			lead.Opportunities = lead.storedInsights.Opportunities
+			lead.RecommendedServices = lead.storedInsights.RecommendedServices
+		} else {
+			s.addBusinessInsights(&lead)
+			lead.InsightsRefreshedAt = nil
+		}
 		
 		leads = append(leads, lead)
 	}
@@ -127,6 +213,11 @@ func (s *LeadExportService) GetQualifiedLeads(filter LeadFilter) ([]QualifiedLea
 
 // ExportQualifiedLeads exports qualified leads in the specified format
 func (s *LeadExportService) ExportQualifiedLeads(filter LeadFilter, options LeadExportOptions) ([]byte, error) {
+	// A vCard describes a company's contact, so it is always one card per company
+	if options.DedupeByCompany || options.Format == FormatVCard {
+		filter.DedupeByCompany = true
+	}
+
 	leads, err := s.GetQualifiedLeads(filter)
 	if err != nil {
 		return nil, err
@@ -137,27 +228,165 @@ func (s *LeadExportService) ExportQualifiedLeads(filter LeadFilter, options Lead
 		return s.exportToJSON(leads, options)
 	case FormatCSV:
 		return s.exportToCSV(leads, options)
+	case FormatVCard:
+		return s.exportToVCard(leads)
 	default:
 		return nil, fmt.Errorf("unsupported export format: %s", options.Format)
 	}
 }
 
+// CountQualifiedLeads returns how many leads match the filter, ignoring Limit and Offset
+func (s *LeadExportService) CountQualifiedLeads(filter LeadFilter) (int, error) {
+	conditions, args, _ := s.buildFilterConditions(filter)
+
+	count := "COUNT(*)"
+	if filter.DedupeByCompany {
+		count = "COUNT(DISTINCT c.id)"
+	}
+
+	query := `
+		SELECT ` + count + `
+		FROM companies c
+		JOIN company_scores cs ON c.id = cs.company_id
+		JOIN scoring_models sm ON cs.scoring_model_id = sm.id
+		WHERE 1=1`
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRow(query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count qualified leads: %w", err)
+	}
+	return total, nil
+}
+
+// CountRiskIndicators returns, for each triggered risk indicator, how many distinct
+// companies among the filtered leads have it. Limit and Offset select the leads the
+// same way GetQualifiedLeads does.
+func (s *LeadExportService) CountRiskIndicators(filter LeadFilter) (map[string]int, error) {
+	conditions, args, argIndex := s.buildFilterConditions(filter)
+
+	leadsQuery := `
+			SELECT c.id, cs.scoring_model_id
+			FROM companies c
+			JOIN company_scores cs ON c.id = cs.company_id
+			JOIN scoring_models sm ON cs.scoring_model_id = sm.id
+			WHERE 1=1`
+	if len(conditions) > 0 {
+		leadsQuery += " AND " + strings.Join(conditions, " AND ")
+	}
+	leadsQuery += " ORDER BY cs.score DESC, c.ticker ASC, cs.scoring_model_id"
+
+	if filter.Limit != nil {
+		leadsQuery += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, *filter.Limit)
+		argIndex++
+	}
+	if filter.Offset != nil {
+		leadsQuery += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, *filter.Offset)
+	}
+
+	query := `
+		SELECT ri.indicator, COUNT(DISTINCT ri.company_id)
+		FROM (` + leadsQuery + `
+		) leads
+		JOIN company_risk_indicators ri
+		  ON ri.company_id = leads.id AND ri.scoring_model_id = leads.scoring_model_id
+		GROUP BY ri.indicator`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count risk indicators: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var indicator string
+		var count int
+		if err := rows.Scan(&indicator, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan risk indicator count: %w", err)
+		}
+		counts[indicator] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count risk indicators: %w", err)
+	}
+	return counts, nil
+}
+
 // buildFilterQuery constructs the SQL query based on filter criteria
 func (s *LeadExportService) buildFilterQuery(filter LeadFilter) (string, []interface{}) {
-	baseQuery := `
+	conditions, args, argIndex := s.buildFilterConditions(filter)
+
+	// When deduplicating, every matching row contributes its model name before
+	// DISTINCT ON keeps the best-scoring one, and both happen before LIMIT so a
+	// page holds whole companies
+	qualifyingModels := "NULL::text[] AS qualifying_models"
+	if filter.DedupeByCompany {
+		qualifyingModels = `array_agg(sm.name) OVER (
+				PARTITION BY c.id ORDER BY cs.score DESC, cs.scoring_model_id
+				ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING
+			) AS qualifying_models`
+	}
+
+	query := `
 		SELECT 
 			c.id, c.ticker, c.company_name, c.market_tier, c.quote_status,
 			c.trading_volume, c.website, c.description, c.officers, c.address,
 			c.transfer_agent, c.auditor, c.last_10k_date, c.last_10q_date,
 			c.last_filing_date, c.profile_verified,
 			cs.scoring_model_id, sm.name as model_name, cs.score,
-			cs.score_breakdown, cs.scored_at
+			cs.score_breakdown, cs.scored_at, COALESCE(cs.score_percent, 0), c.cusip,
+			cs.insights, cs.insights_version, cs.insights_refreshed_at,
+			NULLIF(sm.rules->>'minimum_score', '')::int, cs.requirements_met, cs.qualified_since,
+			cc.name, cc.title, cc.email, cc.phone,
+			` + qualifyingModels + `
 		FROM companies c
 		JOIN company_scores cs ON c.id = cs.company_id
 		JOIN scoring_models sm ON cs.scoring_model_id = sm.id
+		LEFT JOIN LATERAL (
+			SELECT name, title, email, phone
+			FROM company_contacts
+			WHERE company_id = c.id
+			ORDER BY enriched_at DESC NULLS LAST
+			LIMIT 1
+		) cc ON true
 		WHERE 1=1
 	`
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	if filter.DedupeByCompany {
+		query = "SELECT * FROM (SELECT DISTINCT ON (c.id)" + strings.TrimPrefix(strings.TrimSpace(query), "SELECT") +
+			" ORDER BY c.id, cs.score DESC, cs.scoring_model_id) leads" +
+			" ORDER BY score DESC, ticker ASC, scoring_model_id"
+	} else {
+		query += " ORDER BY cs.score DESC, c.ticker ASC, cs.scoring_model_id"
+	}
 
+	// Add limit and offset if specified
+	if filter.Limit != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, *filter.Limit)
+		argIndex++
+	}
+
+	if filter.Offset != nil {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, *filter.Offset)
+	}
+
+	return query, args
+}
+
+// buildFilterConditions translates filter criteria into WHERE conditions over the
+// companies c, company_scores cs and scoring_models sm join, returning the next
+// free placeholder index
+func (s *LeadExportService) buildFilterConditions(filter LeadFilter) ([]string, []interface{}, int) {
 	var conditions []string
 	var args []interface{}
 	argIndex := 1
@@ -173,6 +402,20 @@ func (s *LeadExportService) buildFilterQuery(filter LeadFilter) (string, []inter
 		conditions = append(conditions, fmt.Sprintf("cs.scoring_model_id IN (%s)", strings.Join(placeholders, ",")))
 	}
 
+	// Filter by triggered risk indicators via the normalized index
+	if len(filter.RiskIndicators) > 0 {
+		placeholders := make([]string, len(filter.RiskIndicators))
+		for i, indicator := range filter.RiskIndicators {
+			placeholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, indicator)
+			argIndex++
+		}
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM company_risk_indicators ri
+			WHERE ri.company_id = c.id AND ri.scoring_model_id = cs.scoring_model_id
+			  AND ri.indicator IN (%s))`, strings.Join(placeholders, ",")))
+	}
+
 	// Filter by score range
 	if filter.MinScore != nil {
 		conditions = append(conditions, fmt.Sprintf("cs.score >= $%d", argIndex))
@@ -190,6 +433,10 @@ func (s *LeadExportService) buildFilterQuery(filter LeadFilter) (string, []inter
 	if len(filter.MarketTiers) > 0 {
 		placeholders := make([]string, len(filter.MarketTiers))
 		for i, tier := range filter.MarketTiers {
+			// Accept raw tier text such as "OTC Pink" as well as canonical values
+			if canonical := models.NormalizeMarketTier(tier); canonical != "" {
+				tier = canonical
+			}
 			placeholders[i] = fmt.Sprintf("$%d", argIndex)
 			args = append(args, tier)
 			argIndex++
@@ -261,28 +508,59 @@ func (s *LeadExportService) buildFilterQuery(filter LeadFilter) (string, []inter
 		}
 	}
 
-	// Include requirements met filter if requested
-	if filter.IncludeRequiredOnly {
-		// This would require parsing the score breakdown JSON
-		// For now, we'll use a simple score threshold
-		conditions = append(conditions, "cs.score >= 3")
+	// Filter by reachable contacts: any enriched contact with an email or phone
+	if filter.HasContactInfo != nil {
+		contactExists := `EXISTS (
+			SELECT 1 FROM company_contacts ct
+			WHERE ct.company_id = c.id
+			  AND ((ct.email IS NOT NULL AND ct.email != '') OR (ct.phone IS NOT NULL AND ct.phone != '')))`
+		if *filter.HasContactInfo {
+			conditions = append(conditions, contactExists)
+		} else {
+			conditions = append(conditions, "NOT "+contactExists)
+		}
 	}
 
-	// Build final query
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " AND " + strings.Join(conditions, " AND ")
+	// Filter by how long ago the company moved into its current tier; companies
+	// whose tier never changed are excluded by either bound
+	if filter.MinDaysSinceTierChange != nil {
+		conditions = append(conditions, fmt.Sprintf("%s <= NOW() - $%d::int * INTERVAL '1 day'", repository.TierChangedAtExpr, argIndex))
+		args = append(args, *filter.MinDaysSinceTierChange)
+		argIndex++
 	}
 
-	query += " ORDER BY cs.score DESC, c.ticker ASC"
+	if filter.MaxDaysSinceTierChange != nil {
+		conditions = append(conditions, fmt.Sprintf("%s >= NOW() - $%d::int * INTERVAL '1 day'", repository.TierChangedAtExpr, argIndex))
+		args = append(args, *filter.MaxDaysSinceTierChange)
+		argIndex++
+	}
 
-	// Add limit if specified
-	if filter.Limit != nil {
-		query += fmt.Sprintf(" LIMIT $%d", argIndex)
-		args = append(args, *filter.Limit)
+	// Filter by when the company became qualified, so newly qualified companies can
+	// be told apart from long-standing ones; unqualified scores have no date and
+	// are excluded by either bound
+	if filter.QualifiedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("cs.qualified_since >= $%d", argIndex))
+		args = append(args, *filter.QualifiedAfter)
+		argIndex++
 	}
 
-	return query, args
+	if filter.QualifiedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("cs.qualified_since < $%d", argIndex))
+		args = append(args, *filter.QualifiedBefore)
+		argIndex++
+	}
+
+	// Include requirements met filter if requested
+	if filter.IncludeRequiredOnly {
+		// Use the recorded requirements, approximating scores whose requirements are
+		// still unknown with the model's own minimum_score or the configured default
+		conditions = append(conditions, fmt.Sprintf(
+			"COALESCE(cs.requirements_met, cs.score >= COALESCE(NULLIF(sm.rules->>'minimum_score', '')::int, $%d))", argIndex))
+		args = append(args, s.requiredMinScore)
+		argIndex++
+	}
+
+	return conditions, args, argIndex
 }
 
 // scanQualifiedLead scans a database row into a QualifiedLead struct
@@ -294,12 +572,23 @@ func (s *LeadExportService) scanQualifiedLead(rows *sql.Rows) (QualifiedLead, er
 	var website, description, transferAgent, auditor sql.NullString
 	var last10K, last10Q, lastFiling sql.NullTime
 	var profileVerified sql.NullBool
+	var insightsJSON []byte
+	var storedVersion sql.NullInt64
+	var insightsRefreshedAt sql.NullTime
+	var modelMinScore sql.NullInt64
+	var requirementsMet sql.NullBool
+	var qualifiedSince sql.NullTime
+	var contactName, contactTitle, contactEmail, contactPhone sql.NullString
 
 	err := rows.Scan(
 		&lead.ID, &lead.Ticker, &lead.CompanyName, &lead.MarketTier, &lead.QuoteStatus,
 		&tradingVolume, &website, &description, &officers, &address,
 		&transferAgent, &auditor, &last10K, &last10Q, &lastFiling, &profileVerified,
 		&lead.ModelID, &lead.ModelName, &lead.Score, &breakdownJSON, &lead.ScoredAt,
+		&lead.ScorePercent, &lead.CUSIP,
+		&insightsJSON, &storedVersion, &insightsRefreshedAt, &modelMinScore, &requirementsMet, &qualifiedSince,
+		&contactName, &contactTitle, &contactEmail, &contactPhone,
+		pq.Array(&lead.QualifyingModels),
 	)
 	if err != nil {
 		return lead, err
@@ -339,15 +628,52 @@ func (s *LeadExportService) scanQualifiedLead(rows *sql.Rows) (QualifiedLead, er
 	if profileVerified.Valid {
 		lead.ProfileVerified = &profileVerified.Bool
 	}
+	if qualifiedSince.Valid {
+		lead.QualifiedSince = &qualifiedSince.Time
+	}
+	if contactName.Valid {
+		lead.ContactName = &contactName.String
+	}
+	if contactTitle.Valid {
+		lead.ContactTitle = &contactTitle.String
+	}
+	if contactEmail.Valid {
+		lead.ContactEmail = &contactEmail.String
+	}
+	if contactPhone.Valid {
+		lead.ContactPhone = &contactPhone.String
+	}
 
 	// Parse score breakdown
 	if err := json.Unmarshal([]byte(breakdownJSON), &lead.ScoreBreakdown); err != nil {
 		return lead, fmt.Errorf("failed to unmarshal score breakdown: %w", err)
 	}
 
-	// Determine qualification status from breakdown
-	lead.Qualified = lead.Score >= 3 // Minimum threshold
-	lead.RequirementsMet = true // Assume requirements met if scored
+	// Keep the stored insights snapshot only if it was produced by the current
+	// logic after the latest scoring run
+	if insightsJSON != nil && storedVersion.Valid && int(storedVersion.Int64) == insightsVersion &&
+		insightsRefreshedAt.Valid && !insightsRefreshedAt.Time.Before(lead.ScoredAt) {
+		var insights LeadInsights
+		if err := json.Unmarshal(insightsJSON, &insights); err == nil {
+			lead.storedInsights = &insights
+			lead.InsightsRefreshedAt = &insightsRefreshedAt.Time
+		}
+	}
+
+	// Determine qualification status from the model's own threshold
+	minScore := s.requiredMinScore
+	if modelMinScore.Valid {
+		minScore = int(modelMinScore.Int64)
+	}
+	lead.Qualified = lead.Score >= minScore
+
+	// Scores stored before requirements were recorded fall back to the threshold
+	// until the requirements sweeper recomputes them
+	if requirementsMet.Valid {
+		lead.RequirementsMet = requirementsMet.Bool
+	} else {
+		lead.RequirementsMet = lead.Qualified
+	}
 
 	return lead, nil
 }
@@ -374,9 +700,21 @@ func (s *LeadExportService) addBusinessInsights(lead *QualifiedLead) {
 			case "reverse_merger_shell":
 				riskIndicators = append(riskIndicators, "Shell company structure")
 				services = append(services, "Corporate Restructuring Services")
+			case "confirmed_shell":
+				riskIndicators = append(riskIndicators, "Confirmed shell company")
+				services = append(services, "Corporate Restructuring Services")
 			case "cannabis_or_crypto":
 				riskIndicators = append(riskIndicators, "High-risk industry")
 				services = append(services, "Compliance Advisory Services")
+			case "problematic_auditor":
+				riskIndicators = append(riskIndicators, "Auditor on problematic-firm list")
+				services = append(services, "Auditor Transition Services")
+			case "caveat_emptor":
+				riskIndicators = append(riskIndicators, "Caveat Emptor designation")
+				services = append(services, "Caveat Emptor Removal Services")
+			case "paid_promotion":
+				riskIndicators = append(riskIndicators, "Paid stock promotion")
+				services = append(services, "Investor Relations Compliance Review")
 			case "holding_company_or_spac":
 				opportunities = append(opportunities, "Investment vehicle structure")
 				services = append(services, "M&A Advisory Services")
@@ -386,10 +724,10 @@ func (s *LeadExportService) addBusinessInsights(lead *QualifiedLead) {
 
 	// Market tier specific insights
 	switch lead.MarketTier {
-	case "Expert Market":
+	case models.MarketTierExpertMarket:
 		riskIndicators = append(riskIndicators, "Highest risk tier")
 		services = append(services, "Market Tier Upgrade Services")
-	case "Pink Limited":
+	case models.MarketTierPinkLimited:
 		riskIndicators = append(riskIndicators, "Limited information available")
 		services = append(services, "Information Enhancement Services")
 	}
@@ -402,7 +740,76 @@ func (s *LeadExportService) addBusinessInsights(lead *QualifiedLead) {
 
 	lead.RiskIndicators = riskIndicators
 	lead.Opportunities = opportunities
-	lead.RecommendedServices = services
+	lead.RecommendedServices = dedupeStrings(services)
+}
+
+// dedupeStrings drops repeated values, keeping the first occurrence of each.
+// Several rules recommend the same service (e.g. both shell rules).
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var deduped []string
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		deduped = append(deduped, value)
+	}
+	return deduped
+}
+
+// RefreshInsights recomputes business insights for every lead matching the filter
+// and persists them as the current snapshot, without re-scoring
+func (s *LeadExportService) RefreshInsights(ctx context.Context, filter LeadFilter) (*InsightsRefreshResult, error) {
+	start := time.Now()
+
+	filter.UseStoredInsights = false
+	leads, err := s.GetQualifiedLeads(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE company_scores
+		SET insights = $1, insights_version = $2, insights_refreshed_at = $3
+		WHERE company_id = $4 AND scoring_model_id = $5`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare insights update: %w", err)
+	}
+	defer stmt.Close()
+
+	refreshedAt := time.Now()
+	for _, lead := range leads {
+		insightsJSON, err := json.Marshal(LeadInsights{
+			RiskIndicators:      lead.RiskIndicators,
+			Opportunities:       lead.Opportunities,
+			RecommendedServices: lead.RecommendedServices,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal insights for %s: %w", lead.Ticker, err)
+		}
+
+		if _, err := stmt.ExecContext(ctx, insightsJSON, insightsVersion, refreshedAt, lead.ID, lead.ModelID); err != nil {
+			return nil, fmt.Errorf("failed to store insights for %s: %w", lead.Ticker, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit insights refresh: %w", err)
+	}
+
+	return &InsightsRefreshResult{
+		Version:     insightsVersion,
+		Refreshed:   len(leads),
+		Duration:    time.Since(start),
+		CompletedAt: time.Now(),
+	}, nil
 }
 
 // exportToJSON exports leads to JSON format
@@ -424,6 +831,7 @@ func (s *LeadExportService) exportToJSON(leads []QualifiedLead, options LeadExpo
 		exportData["metadata"] = map[string]interface{}{
 			"export_format": "json",
 			"include_score_breakdown": options.IncludeScoreBreakdown,
+			"dedupe_by_company": options.DedupeByCompany,
 			"total_companies": len(leads),
 		}
 	}
@@ -442,8 +850,9 @@ func (s *LeadExportService) exportToCSV(leads []QualifiedLead, options LeadExpor
 		"trading_volume", "website", "description", "officers", "address",
 		"transfer_agent", "auditor", "last_10k_date", "last_10q_date",
 		"last_filing_date", "profile_verified", "model_id", "model_name",
-		"score", "qualified", "requirements_met", "scored_at",
+		"score", "score_percent", "qualified", "requirements_met", "scored_at",
 		"risk_indicators", "opportunities", "recommended_services",
+		"qualifying_models", "cusip", "qualified_since",
 	}
 
 	if err := writer.Write(headers); err != nil {
@@ -472,12 +881,16 @@ func (s *LeadExportService) exportToCSV(leads []QualifiedLead, options LeadExpor
 			lead.ModelID,
 			lead.ModelName,
 			strconv.Itoa(lead.Score),
+			strconv.FormatFloat(lead.ScorePercent, 'f', 1, 64),
 			strconv.FormatBool(lead.Qualified),
 			strconv.FormatBool(lead.RequirementsMet),
 			lead.ScoredAt.Format(time.RFC3339),
 			strings.Join(lead.RiskIndicators, "; "),
 			strings.Join(lead.Opportunities, "; "),
 			strings.Join(lead.RecommendedServices, "; "),
+			strings.Join(lead.QualifyingModels, "; "),
+			lead.CUSIP,
+			s.formatNullTime(lead.QualifiedSince),
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -493,6 +906,143 @@ func (s *LeadExportService) exportToCSV(leads []QualifiedLead, options LeadExpor
 	return []byte(output.String()), nil
 }
 
+// exportToVCard exports leads as vCard 3.0 contacts, one card per company, for CRM import.
+// The enriched contact is preferred, falling back to the first listed officer and then
+// the company itself; the ticker and model score are carried in the NOTE field.
+func (s *LeadExportService) exportToVCard(leads []QualifiedLead) ([]byte, error) {
+	var output strings.Builder
+
+	// Leads arrive best score first, so the first row per company is the one to keep
+	written := make(map[string]bool, len(leads))
+	for _, lead := range leads {
+		if written[lead.ID] {
+			continue
+		}
+		written[lead.ID] = true
+
+		name := s.formatNullString(lead.ContactName)
+		title := s.formatNullString(lead.ContactTitle)
+		if name == "" {
+			if officer, ok := firstOfficer(lead.Officers); ok {
+				name = officer.Name
+				if title == "" {
+					title = officer.Title
+				}
+			}
+		}
+
+		writeVCardLine(&output, "BEGIN", "VCARD")
+		writeVCardLine(&output, "VERSION", "3.0")
+		if name != "" {
+			writeVCardLine(&output, "FN", escapeVCard(name))
+			writeVCardLine(&output, "N", vCardStructuredName(name))
+		} else {
+			writeVCardLine(&output, "FN", escapeVCard(lead.CompanyName))
+			writeVCardLine(&output, "N", ";;;;")
+		}
+		writeVCardLine(&output, "ORG", escapeVCard(lead.CompanyName))
+		if title != "" {
+			writeVCardLine(&output, "TITLE", escapeVCard(title))
+		}
+		if email := s.formatNullString(lead.ContactEmail); email != "" {
+			writeVCardLine(&output, "EMAIL;TYPE=INTERNET,WORK", escapeVCard(email))
+		}
+		if phone := s.formatNullString(lead.ContactPhone); phone != "" {
+			writeVCardLine(&output, "TEL;TYPE=WORK,VOICE", escapeVCard(phone))
+		}
+		// URL is a URI value, not text, so it is written unescaped
+		if website := s.formatNullString(lead.Website); website != "" {
+			writeVCardLine(&output, "URL", website)
+		}
+		if lead.Address != nil {
+			var address models.Address
+			if err := json.Unmarshal([]byte(*lead.Address), &address); err == nil {
+				writeVCardLine(&output, "ADR;TYPE=WORK", strings.Join([]string{
+					"", "",
+					escapeVCard(address.Street),
+					escapeVCard(address.City),
+					escapeVCard(address.State),
+					escapeVCard(address.PostCode),
+					escapeVCard(address.Country),
+				}, ";"))
+			}
+		}
+		note := fmt.Sprintf("Ticker: %s\nModel: %s\nScore: %d (%.1f%%)",
+			lead.Ticker, lead.ModelName, lead.Score, lead.ScorePercent)
+		if len(lead.QualifyingModels) > 1 {
+			note += "\nQualifying models: " + strings.Join(lead.QualifyingModels, ", ")
+		}
+		writeVCardLine(&output, "NOTE", escapeVCard(note))
+		writeVCardLine(&output, "END", "VCARD")
+	}
+
+	return []byte(output.String()), nil
+}
+
+// firstOfficer returns the first named officer from a lead's officers JSON
+func firstOfficer(officersJSON *string) (models.Officer, bool) {
+	if officersJSON == nil {
+		return models.Officer{}, false
+	}
+
+	var officers models.Officers
+	if err := json.Unmarshal([]byte(*officersJSON), &officers); err != nil {
+		return models.Officer{}, false
+	}
+	for _, officer := range officers {
+		if strings.TrimSpace(officer.Name) != "" {
+			return officer, true
+		}
+	}
+	return models.Officer{}, false
+}
+
+// vCardStructuredName builds the N property (family;given;additional;prefix;suffix)
+// from a display name, treating the last word as the family name
+func vCardStructuredName(name string) string {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return ";;;;"
+	}
+	family := parts[len(parts)-1]
+	given := strings.Join(parts[:len(parts)-1], " ")
+	return escapeVCard(family) + ";" + escapeVCard(given) + ";;;"
+}
+
+// escapeVCard escapes text property values per RFC 2426
+func escapeVCard(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	value = strings.ReplaceAll(value, ",", "\\,")
+	value = strings.ReplaceAll(value, ";", "\\;")
+	return value
+}
+
+// vCardMaxLineOctets is the longest content line RFC 6350 section 3.2 allows before folding
+const vCardMaxLineOctets = 75
+
+// writeVCardLine writes a CRLF-terminated content line, folding it onto continuation
+// lines that start with a space once it exceeds vCardMaxLineOctets. Folds never split
+// a multi-byte UTF-8 character.
+func writeVCardLine(output *strings.Builder, property, value string) {
+	line := property + ":" + value
+	limit := vCardMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		output.WriteString(line[:cut])
+		output.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts toward its length
+		limit = vCardMaxLineOctets - 1
+	}
+	output.WriteString(line)
+	output.WriteString("\r\n")
+}
+
 // Helper functions for CSV formatting
 func (s *LeadExportService) formatNullString(val *string) string {
 	if val == nil {
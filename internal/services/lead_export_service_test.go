@@ -0,0 +1,314 @@
+package services
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// parseVCards unfolds content lines and splits the output into cards of property -> value,
+// failing the test on lines that break RFC 6350 framing
+func parseVCards(t *testing.T, output string) []map[string]string {
+	t.Helper()
+
+	if !strings.HasSuffix(output, "\r\n") {
+		t.Fatalf("Expected output to end with CRLF, got %q", output)
+	}
+
+	var lines []string
+	for _, physical := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+		if len(physical) > vCardMaxLineOctets {
+			t.Errorf("Line exceeds %d octets (%d): %q", vCardMaxLineOctets, len(physical), physical)
+		}
+		if strings.HasPrefix(physical, " ") {
+			if len(lines) == 0 {
+				t.Fatalf("Continuation line with nothing to continue: %q", physical)
+			}
+			lines[len(lines)-1] += physical[1:]
+			continue
+		}
+		lines = append(lines, physical)
+	}
+
+	var cards []map[string]string
+	var card map[string]string
+	for _, line := range lines {
+		property, value, ok := strings.Cut(line, ":")
+		if !ok {
+			t.Fatalf("Content line without a value: %q", line)
+		}
+		switch {
+		case property == "BEGIN" && value == "VCARD":
+			card = map[string]string{}
+		case property == "END" && value == "VCARD":
+			cards = append(cards, card)
+			card = nil
+		case card == nil:
+			t.Fatalf("Property outside a card: %q", line)
+		default:
+			card[property] = value
+		}
+	}
+	if card != nil {
+		t.Fatal("Unterminated card")
+	}
+	return cards
+}
+
+func TestExportToVCard_ParsesAsOneCardPerCompany(t *testing.T) {
+	s := &LeadExportService{}
+
+	website := "https://example.com/investors?ref=otc,pink;a=b"
+	contact := "Jane Q. Public"
+	longName := "International Consolidated Holdings and Acquisition Corporation of America, Inc."
+	leads := []QualifiedLead{
+		{ID: "c1", Ticker: "ABCD", CompanyName: longName, Website: &website, ContactName: &contact,
+			ModelName: "Distressed", Score: 9, QualifyingModels: []string{"Distressed", "Shell"}},
+		{ID: "c1", Ticker: "ABCD", CompanyName: longName, Website: &website, ContactName: &contact,
+			ModelName: "Shell", Score: 4},
+		{ID: "c2", Ticker: "WXYZ", CompanyName: "Café Résumé Ünlimited", ModelName: "Distressed", Score: 5},
+	}
+
+	output, err := s.exportToVCard(leads)
+	if err != nil {
+		t.Fatalf("exportToVCard failed: %v", err)
+	}
+
+	cards := parseVCards(t, string(output))
+	if len(cards) != 2 {
+		t.Fatalf("Expected one card per company (2), got %d", len(cards))
+	}
+
+	first := cards[0]
+	if first["URL"] != website {
+		t.Errorf("Expected URL to be written unescaped, got %q", first["URL"])
+	}
+	if first["ORG"] != strings.ReplaceAll(longName, ",", "\\,") {
+		t.Errorf("Expected long ORG to unfold to the escaped name, got %q", first["ORG"])
+	}
+	if first["FN"] != contact {
+		t.Errorf("Expected FN %q, got %q", contact, first["FN"])
+	}
+	if !strings.Contains(first["NOTE"], "Model: Distressed") || !strings.Contains(first["NOTE"], "Qualifying models: Distressed\\, Shell") {
+		t.Errorf("Expected NOTE to carry the best model and all qualifying models, got %q", first["NOTE"])
+	}
+
+	if cards[1]["ORG"] != "Café Résumé Ünlimited" {
+		t.Errorf("Expected multi-byte ORG to survive folding, got %q", cards[1]["ORG"])
+	}
+}
+
+func TestWriteVCardLine_FoldsWithoutSplittingRunes(t *testing.T) {
+	var output strings.Builder
+	value := strings.Repeat("é", 100)
+	writeVCardLine(&output, "NOTE", value)
+
+	cards := parseVCards(t, "BEGIN:VCARD\r\n"+output.String()+"END:VCARD\r\n")
+	if len(cards) != 1 || cards[0]["NOTE"] != value {
+		t.Errorf("Expected folded line to unfold to the original value, got %q", cards)
+	}
+}
+
+func TestBuildFilterQuery_DedupesBeforeLimit(t *testing.T) {
+	s := &LeadExportService{requiredMinScore: defaultRequiredMinScore}
+	limit, offset := 10, 20
+
+	query, args := s.buildFilterQuery(LeadFilter{DedupeByCompany: true, Limit: &limit, Offset: &offset})
+
+	distinct := strings.Index(query, "DISTINCT ON (c.id)")
+	aggregate := strings.Index(query, "array_agg(sm.name) OVER")
+	limitAt := strings.Index(query, "LIMIT $1")
+	if distinct < 0 || aggregate < 0 || limitAt < 0 {
+		t.Fatalf("Expected DISTINCT ON, array_agg and LIMIT in query, got %s", query)
+	}
+	if limitAt < strings.LastIndex(query, ") leads") {
+		t.Errorf("Expected LIMIT to apply to the deduplicated rows, got %s", query)
+	}
+	if len(args) != 2 || args[0] != limit || args[1] != offset {
+		t.Errorf("Expected limit and offset args, got %v", args)
+	}
+
+	plain, _ := s.buildFilterQuery(LeadFilter{})
+	if strings.Contains(plain, "DISTINCT ON") {
+		t.Errorf("Expected no deduplication unless requested, got %s", plain)
+	}
+}
+
+// newLeadTestDB connects to TEST_DATABASE_URL on a single connection with
+// temporary lead tables shadowing the real ones for the session
+func newLeadTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("Skipping lead export test - TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		t.Skip("Skipping lead export test - no database available")
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+	if err := db.Ping(); err != nil {
+		t.Skip("Database ping failed - connection not available for testing")
+	}
+
+	_, err = db.Exec(`
+		CREATE TEMP TABLE companies (
+			id UUID PRIMARY KEY,
+			ticker VARCHAR(10) NOT NULL,
+			company_name VARCHAR(255) NOT NULL,
+			market_tier VARCHAR(50) NOT NULL DEFAULT '',
+			quote_status VARCHAR(50) NOT NULL DEFAULT '',
+			trading_volume BIGINT,
+			website VARCHAR(500),
+			description TEXT,
+			officers TEXT,
+			address TEXT,
+			transfer_agent VARCHAR(255),
+			auditor VARCHAR(255),
+			last_10k_date DATE,
+			last_10q_date DATE,
+			last_filing_date DATE,
+			profile_verified BOOLEAN,
+			cusip VARCHAR(20) NOT NULL DEFAULT ''
+		);
+		CREATE TEMP TABLE scoring_models (
+			id UUID PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			rules JSONB NOT NULL DEFAULT '{}'
+		);
+		CREATE TEMP TABLE company_scores (
+			company_id UUID NOT NULL,
+			scoring_model_id UUID NOT NULL,
+			score INTEGER NOT NULL,
+			qualified BOOLEAN NOT NULL DEFAULT true,
+			requirements_met BOOLEAN,
+			score_breakdown JSONB NOT NULL DEFAULT '{}',
+			scored_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			score_percent NUMERIC(5,1),
+			qualified_since TIMESTAMP,
+			insights JSONB,
+			insights_version INTEGER,
+			insights_refreshed_at TIMESTAMP
+		);
+		CREATE TEMP TABLE company_risk_indicators (
+			company_id UUID NOT NULL,
+			scoring_model_id UUID NOT NULL,
+			indicator VARCHAR(100) NOT NULL,
+			points INTEGER NOT NULL,
+			PRIMARY KEY (company_id, scoring_model_id, indicator)
+		);
+		CREATE TEMP TABLE company_contacts (
+			company_id UUID,
+			name VARCHAR(255),
+			email VARCHAR(255),
+			title VARCHAR(255),
+			phone VARCHAR(50),
+			enriched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		t.Fatalf("Failed to create temporary lead tables: %v", err)
+	}
+	return db
+}
+
+func TestGetQualifiedLeads_DedupeKeepsWholeCompaniesWithinLimit(t *testing.T) {
+	db := newLeadTestDB(t)
+
+	_, err := db.Exec(`
+		INSERT INTO companies (id, ticker, company_name) VALUES
+			('00000000-0000-0000-0000-00000000000a', 'AAAA', 'Alpha'),
+			('00000000-0000-0000-0000-00000000000b', 'BBBB', 'Beta');
+		INSERT INTO scoring_models (id, name) VALUES
+			('00000000-0000-0000-0000-000000000001', 'Distressed'),
+			('00000000-0000-0000-0000-000000000002', 'Shell'),
+			('00000000-0000-0000-0000-000000000003', 'Dormant');
+		INSERT INTO company_scores (company_id, scoring_model_id, score) VALUES
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000001', 9),
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000002', 8),
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000003', 2),
+			('00000000-0000-0000-0000-00000000000b', '00000000-0000-0000-0000-000000000002', 7)`)
+	if err != nil {
+		t.Fatalf("Failed to seed leads: %v", err)
+	}
+
+	s := NewLeadExportService(db, nil)
+	limit := 2
+	filter := LeadFilter{DedupeByCompany: true, Limit: &limit}
+
+	leads, err := s.GetQualifiedLeads(filter)
+	if err != nil {
+		t.Fatalf("GetQualifiedLeads failed: %v", err)
+	}
+	if len(leads) != 2 {
+		t.Fatalf("Expected a full page of 2 companies, got %d", len(leads))
+	}
+	if leads[0].Ticker != "AAAA" || leads[0].ModelName != "Distressed" {
+		t.Errorf("Expected Alpha under its best model first, got %s/%s", leads[0].Ticker, leads[0].ModelName)
+	}
+	if got := strings.Join(leads[0].QualifyingModels, ","); got != "Distressed,Shell,Dormant" {
+		t.Errorf("Expected every qualifying model for Alpha, got %q", got)
+	}
+	if leads[1].Ticker != "BBBB" || strings.Join(leads[1].QualifyingModels, ",") != "Shell" {
+		t.Errorf("Expected Beta with its single model second, got %s %v", leads[1].Ticker, leads[1].QualifyingModels)
+	}
+
+	total, err := s.CountQualifiedLeads(filter)
+	if err != nil {
+		t.Fatalf("CountQualifiedLeads failed: %v", err)
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 companies counted, got %d", total)
+	}
+}
+
+func TestCountRiskIndicators_CountsCompaniesAmongFilteredLeads(t *testing.T) {
+	db := newLeadTestDB(t)
+
+	_, err := db.Exec(`
+		INSERT INTO companies (id, ticker, company_name) VALUES
+			('00000000-0000-0000-0000-00000000000a', 'AAAA', 'Alpha'),
+			('00000000-0000-0000-0000-00000000000b', 'BBBB', 'Beta'),
+			('00000000-0000-0000-0000-00000000000c', 'CCCC', 'Gamma');
+		INSERT INTO scoring_models (id, name) VALUES
+			('00000000-0000-0000-0000-000000000001', 'Distressed'),
+			('00000000-0000-0000-0000-000000000002', 'Shell');
+		INSERT INTO company_scores (company_id, scoring_model_id, score) VALUES
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000001', 9),
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000002', 8),
+			('00000000-0000-0000-0000-00000000000b', '00000000-0000-0000-0000-000000000001', 7),
+			('00000000-0000-0000-0000-00000000000c', '00000000-0000-0000-0000-000000000001', 1);
+		INSERT INTO company_risk_indicators (company_id, scoring_model_id, indicator, points) VALUES
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000001', 'delinquent_10k', 3),
+			('00000000-0000-0000-0000-00000000000a', '00000000-0000-0000-0000-000000000002', 'delinquent_10k', 2),
+			('00000000-0000-0000-0000-00000000000b', '00000000-0000-0000-0000-000000000001', 'delinquent_10k', 3),
+			('00000000-0000-0000-0000-00000000000b', '00000000-0000-0000-0000-000000000001', 'no_website', 1),
+			('00000000-0000-0000-0000-00000000000c', '00000000-0000-0000-0000-000000000001', 'no_website', 1)`)
+	if err != nil {
+		t.Fatalf("Failed to seed leads: %v", err)
+	}
+
+	s := NewLeadExportService(db, nil)
+	minScore := 5
+	counts, err := s.CountRiskIndicators(LeadFilter{MinScore: &minScore})
+	if err != nil {
+		t.Fatalf("CountRiskIndicators failed: %v", err)
+	}
+	if counts["delinquent_10k"] != 2 || counts["no_website"] != 1 || len(counts) != 2 {
+		t.Errorf("Expected each filtered company counted once per indicator, got %v", counts)
+	}
+
+	limit := 2
+	counts, err = s.CountRiskIndicators(LeadFilter{MinScore: &minScore, Limit: &limit})
+	if err != nil {
+		t.Fatalf("CountRiskIndicators failed: %v", err)
+	}
+	if counts["delinquent_10k"] != 1 || counts["no_website"] != 0 {
+		t.Errorf("Expected counts over only the limited leads, got %v", counts)
+	}
+}
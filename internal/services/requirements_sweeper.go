@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+// RequirementsSweeper rescores stored scores whose requirements_met is unknown, a
+// batch at a time, so the dataset converges to correct values without a full rescore
+type RequirementsSweeper struct {
+	store          requirementsSweepStore
+	scoringService requirementsRescorer
+	batchSize      int
+	interval       time.Duration
+	remaining      int64 // updated atomically after each sweep; -1 until the first count
+	isRunning      bool
+	stopChan       chan struct{}
+	wg             sync.WaitGroup
+	mu             sync.Mutex
+}
+
+// requirementsRescorer is the part of ScoringService the sweeper uses
+type requirementsRescorer interface {
+	ScoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error)
+}
+
+// scoreKey identifies one stored score
+type scoreKey struct{ companyID, modelID string }
+
+// requirementsSweepStore finds and marks scores with unknown requirements
+type requirementsSweepStore interface {
+	// PendingScores returns up to limit scores with unknown requirements, never-attempted
+	// ones first, then least recently attempted, then oldest
+	PendingScores(ctx context.Context, limit int) ([]scoreKey, error)
+	// MarkAttempted records a failed rescore so the score moves to the back of the queue
+	MarkAttempted(ctx context.Context, key scoreKey) error
+	// CountPending returns how many scores still have unknown requirements
+	CountPending(ctx context.Context) (int64, error)
+}
+
+// RequirementsSweepResult summarizes a single sweep
+type RequirementsSweepResult struct {
+	Rescored    int           `json:"rescored"`
+	Failed      int           `json:"failed"`
+	Remaining   int64         `json:"remaining"`
+	Duration    time.Duration `json:"duration"`
+	CompletedAt time.Time     `json:"completed_at"`
+}
+
+// NewRequirementsSweeper creates a requirements sweeper from configuration
+func NewRequirementsSweeper(db *sql.DB, scoringService ScoringService, cfg *config.Config) *RequirementsSweeper {
+	batchSize := cfg.RequirementsSweepBatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &RequirementsSweeper{
+		store:          &sqlRequirementsSweepStore{db: db},
+		scoringService: scoringService,
+		batchSize:      batchSize,
+		interval:       time.Duration(cfg.RequirementsSweepIntervalMinutes) * time.Minute,
+		remaining:      -1,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+// Enabled reports whether a sweep interval is configured
+func (r *RequirementsSweeper) Enabled() bool {
+	return r.interval > 0
+}
+
+// Remaining returns how many scores still lack requirements_met as of the last
+// sweep, or -1 before the first sweep has counted them
+func (r *RequirementsSweeper) Remaining() int64 {
+	return atomic.LoadInt64(&r.remaining)
+}
+
+// Start runs a sweep immediately and then on the configured interval until Stop is called
+func (r *RequirementsSweeper) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.Enabled() {
+		return fmt.Errorf("requirements sweeper is disabled (REQUIREMENTS_SWEEP_INTERVAL_MINUTES is 0)")
+	}
+	if r.isRunning {
+		return fmt.Errorf("requirements sweeper is already running")
+	}
+
+	r.isRunning = true
+	r.wg.Add(1)
+	go r.run()
+
+	log.Printf("🧮 Requirements sweeper started: rescoring up to %d scores every %s", r.batchSize, r.interval)
+	return nil
+}
+
+// Stop gracefully stops the background sweeper
+func (r *RequirementsSweeper) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.isRunning {
+		return fmt.Errorf("requirements sweeper is not running")
+	}
+
+	close(r.stopChan)
+	r.wg.Wait()
+	r.isRunning = false
+	return nil
+}
+
+// run is the background sweep loop
+func (r *RequirementsSweeper) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+		if result, err := r.Sweep(ctx); err != nil {
+			log.Printf("❌ Requirements sweep failed: %v", err)
+		} else if result.Rescored > 0 || result.Failed > 0 {
+			log.Printf("✅ Requirements sweep rescored %d scores (%d failed), %d remaining",
+				result.Rescored, result.Failed, result.Remaining)
+		}
+		cancel()
+
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep rescores one batch of scores with unknown requirements and recounts how many
+// remain. Scores that fail to rescore are marked, so later sweeps move past them.
+func (r *RequirementsSweeper) Sweep(ctx context.Context) (*RequirementsSweepResult, error) {
+	start := time.Now()
+
+	pending, err := r.store.PendingScores(ctx, r.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RequirementsSweepResult{}
+	for _, key := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		// Rescoring stores a fresh result, which records requirements_met
+		if _, err := r.scoringService.ScoreCompanyWithModel(key.companyID, key.modelID); err != nil {
+			log.Printf("Failed to rescore company %s with model %s: %v", key.companyID, key.modelID, err)
+			result.Failed++
+			if err := r.store.MarkAttempted(ctx, key); err != nil {
+				log.Printf("Failed to mark sweep attempt for company %s with model %s: %v", key.companyID, key.modelID, err)
+			}
+			continue
+		}
+		result.Rescored++
+	}
+
+	remaining, err := r.store.CountPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Remaining = remaining
+	atomic.StoreInt64(&r.remaining, result.Remaining)
+
+	result.Duration = time.Since(start)
+	result.CompletedAt = time.Now()
+	return result, nil
+}
+
+// sqlRequirementsSweepStore is the Postgres-backed requirementsSweepStore
+type sqlRequirementsSweepStore struct {
+	db *sql.DB
+}
+
+func (st *sqlRequirementsSweepStore) PendingScores(ctx context.Context, limit int) ([]scoreKey, error) {
+	rows, err := st.db.QueryContext(ctx, `
+		SELECT company_id, scoring_model_id
+		FROM company_scores
+		WHERE requirements_met IS NULL
+		ORDER BY requirements_sweep_attempted_at NULLS FIRST, scored_at
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scores with unknown requirements: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []scoreKey
+	for rows.Next() {
+		var key scoreKey
+		if err := rows.Scan(&key.companyID, &key.modelID); err != nil {
+			return nil, fmt.Errorf("failed to scan score: %w", err)
+		}
+		pending = append(pending, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate scores: %w", err)
+	}
+	return pending, nil
+}
+
+func (st *sqlRequirementsSweepStore) MarkAttempted(ctx context.Context, key scoreKey) error {
+	_, err := st.db.ExecContext(ctx, `
+		UPDATE company_scores SET requirements_sweep_attempted_at = NOW()
+		WHERE company_id = $1 AND scoring_model_id = $2`,
+		key.companyID, key.modelID,
+	)
+	return err
+}
+
+func (st *sqlRequirementsSweepStore) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	if err := st.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM company_scores WHERE requirements_met IS NULL`,
+	).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count scores with unknown requirements: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
+)
+
+// fakeSweepScore is a stored score with unknown requirements
+type fakeSweepScore struct {
+	key      scoreKey
+	scoredAt int
+	attempt  int // sequence of the last failed attempt; 0 if never attempted
+}
+
+// fakeSweepStore orders pending scores the way sqlRequirementsSweepStore does
+type fakeSweepStore struct {
+	pending  []*fakeSweepScore
+	attempts int
+}
+
+func (f *fakeSweepStore) PendingScores(ctx context.Context, limit int) ([]scoreKey, error) {
+	sorted := append([]*fakeSweepScore(nil), f.pending...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].attempt != sorted[j].attempt {
+			return sorted[i].attempt < sorted[j].attempt
+		}
+		return sorted[i].scoredAt < sorted[j].scoredAt
+	})
+
+	var keys []scoreKey
+	for i := 0; i < len(sorted) && i < limit; i++ {
+		keys = append(keys, sorted[i].key)
+	}
+	return keys, nil
+}
+
+func (f *fakeSweepStore) MarkAttempted(ctx context.Context, key scoreKey) error {
+	for _, score := range f.pending {
+		if score.key == key {
+			f.attempts++
+			score.attempt = f.attempts
+		}
+	}
+	return nil
+}
+
+func (f *fakeSweepStore) CountPending(ctx context.Context) (int64, error) {
+	return int64(len(f.pending)), nil
+}
+
+// fakeRescorer fails for the configured companies and clears the rest from the store
+type fakeRescorer struct {
+	store    *fakeSweepStore
+	failing  map[string]bool
+	rescored []string
+}
+
+func (f *fakeRescorer) ScoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error) {
+	if f.failing[companyID] {
+		return nil, fmt.Errorf("company %s has no data", companyID)
+	}
+
+	f.rescored = append(f.rescored, companyID)
+	for i, score := range f.store.pending {
+		if score.key == (scoreKey{companyID, modelID}) {
+			f.store.pending = append(f.store.pending[:i], f.store.pending[i+1:]...)
+			break
+		}
+	}
+	return &repository.CompanyScore{}, nil
+}
+
+func TestRequirementsSweeper_MovesPastFailingScores(t *testing.T) {
+	store := &fakeSweepStore{pending: []*fakeSweepScore{
+		{key: scoreKey{"broken-1", "model"}, scoredAt: 1},
+		{key: scoreKey{"broken-2", "model"}, scoredAt: 2},
+		{key: scoreKey{"ok-1", "model"}, scoredAt: 3},
+		{key: scoreKey{"ok-2", "model"}, scoredAt: 4},
+	}}
+	rescorer := &fakeRescorer{store: store, failing: map[string]bool{"broken-1": true, "broken-2": true}}
+	sweeper := &RequirementsSweeper{store: store, scoringService: rescorer, batchSize: 2, remaining: -1}
+
+	first, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if first.Failed != 2 || first.Rescored != 0 {
+		t.Errorf("Expected first sweep to fail 2 and rescore 0, got %d failed, %d rescored", first.Failed, first.Rescored)
+	}
+
+	second, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if second.Rescored != 2 || second.Failed != 0 {
+		t.Errorf("Expected second sweep to rescore the untried scores, got %d rescored, %d failed", second.Rescored, second.Failed)
+	}
+	if len(rescorer.rescored) != 2 || rescorer.rescored[0] != "ok-1" || rescorer.rescored[1] != "ok-2" {
+		t.Errorf("Expected ok-1 and ok-2 to be rescored, got %v", rescorer.rescored)
+	}
+	if second.Remaining != 2 || sweeper.Remaining() != 2 {
+		t.Errorf("Expected 2 remaining, got result %d, sweeper %d", second.Remaining, sweeper.Remaining())
+	}
+}
+
+func TestRequirementsSweeper_RetriesLeastRecentlyAttemptedFirst(t *testing.T) {
+	store := &fakeSweepStore{pending: []*fakeSweepScore{
+		{key: scoreKey{"a", "model"}, scoredAt: 1},
+		{key: scoreKey{"b", "model"}, scoredAt: 2},
+		{key: scoreKey{"c", "model"}, scoredAt: 3},
+	}}
+	rescorer := &fakeRescorer{store: store, failing: map[string]bool{"a": true, "b": true, "c": true}}
+	sweeper := &RequirementsSweeper{store: store, scoringService: rescorer, batchSize: 2, remaining: -1}
+
+	// a and b fail first, then c and the older of the two retries
+	for i := 0; i < 2; i++ {
+		if _, err := sweeper.Sweep(context.Background()); err != nil {
+			t.Fatalf("Sweep failed: %v", err)
+		}
+	}
+
+	next, _ := store.PendingScores(context.Background(), 1)
+	if len(next) != 1 || next[0].companyID != "b" {
+		t.Errorf("Expected b to be the least recently attempted score, got %v", next)
+	}
+}
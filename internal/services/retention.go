@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+// RetentionService prunes old company history snapshots on a schedule
+type RetentionService struct {
+	db            *sql.DB
+	retentionDays int
+	interval      time.Duration
+	isRunning     bool
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+}
+
+// RetentionResult summarizes a single cleanup run
+type RetentionResult struct {
+	RetentionDays  int           `json:"retention_days"`
+	Cutoff         time.Time     `json:"cutoff"`
+	HistoryDeleted int64         `json:"history_deleted"`
+	Duration       time.Duration `json:"duration"`
+	CompletedAt    time.Time     `json:"completed_at"`
+}
+
+// NewRetentionService creates a retention service from configuration
+func NewRetentionService(db *sql.DB, cfg *config.Config) *RetentionService {
+	interval := time.Duration(cfg.RetentionIntervalHours) * time.Hour
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	return &RetentionService{
+		db:            db,
+		retentionDays: cfg.HistoryRetentionDays,
+		interval:      interval,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Enabled reports whether a retention period is configured
+func (r *RetentionService) Enabled() bool {
+	return r.retentionDays > 0
+}
+
+// Start runs the cleanup on the configured interval until Stop is called
+func (r *RetentionService) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.Enabled() {
+		return fmt.Errorf("retention is disabled (HISTORY_RETENTION_DAYS not set)")
+	}
+	if r.isRunning {
+		return fmt.Errorf("retention cleanup is already running")
+	}
+
+	r.isRunning = true
+	r.wg.Add(1)
+	go r.run()
+
+	log.Printf("🧹 Retention cleanup started: keeping %d days of history, every %s", r.retentionDays, r.interval)
+	return nil
+}
+
+// Stop gracefully stops the background cleanup
+func (r *RetentionService) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.isRunning {
+		return fmt.Errorf("retention cleanup is not running")
+	}
+
+	close(r.stopChan)
+	r.wg.Wait()
+	r.isRunning = false
+	return nil
+}
+
+// run is the background cleanup loop
+func (r *RetentionService) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			if result, err := r.RunCleanup(ctx); err != nil {
+				log.Printf("❌ Retention cleanup failed: %v", err)
+			} else {
+				log.Printf("✅ Retention cleanup removed %d history snapshots older than %s",
+					result.HistoryDeleted, result.Cutoff.Format("2006-01-02"))
+			}
+			cancel()
+		}
+	}
+}
+
+// RunCleanup deletes history snapshots older than the retention period,
+// always keeping the most recent snapshot for each company
+func (r *RetentionService) RunCleanup(ctx context.Context) (*RetentionResult, error) {
+	if !r.Enabled() {
+		return nil, fmt.Errorf("retention is disabled (HISTORY_RETENTION_DAYS not set)")
+	}
+
+	start := time.Now()
+	cutoff := start.AddDate(0, 0, -r.retentionDays)
+
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM company_history h
+		WHERE h.scraped_at < $1
+		  AND EXISTS (
+			SELECT 1 FROM company_history newer
+			WHERE newer.company_id = h.company_id AND newer.scraped_at > h.scraped_at
+		  )`,
+		cutoff,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune company history: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pruned history: %w", err)
+	}
+
+	return &RetentionResult{
+		RetentionDays:  r.retentionDays,
+		Cutoff:         cutoff,
+		HistoryDeleted: deleted,
+		Duration:       time.Since(start),
+		CompletedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
+	"github.com/google/uuid"
+)
+
+// ScoringConfigFormatVersion is the current version of the scoring config bundle format
+const ScoringConfigFormatVersion = 1
+
+// Stored list types in scoring_reference_lists
+const (
+	listTypeReference = "reference"
+	listTypeKeyword   = "keyword"
+)
+
+// ScoringConfigBundle is a complete snapshot of the scoring configuration
+type ScoringConfigBundle struct {
+	FormatVersion  int                 `json:"format_version"`
+	ExportedAt     time.Time           `json:"exported_at"`
+	Models         []scoring.ICPModel  `json:"models"`
+	ReferenceLists map[string][]string `json:"reference_lists"`
+	KeywordSets    map[string][]string `json:"keyword_sets"`
+}
+
+// ScoringConfigChange describes a single change an import makes (or would make)
+type ScoringConfigChange struct {
+	Kind   string `json:"kind"` // model, reference_list, keyword_set
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Action string `json:"action"` // create, update, unchanged
+}
+
+// ScoringConfigImportReport summarizes the result of a scoring config import
+type ScoringConfigImportReport struct {
+	DryRun    bool                  `json:"dry_run"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+	Changes   []ScoringConfigChange `json:"changes"`
+}
+
+// ExportScoringConfig builds a versioned bundle of all models, reference lists and keyword sets
+func (s *scoringServiceImpl) ExportScoringConfig() (*ScoringConfigBundle, error) {
+	models, err := s.repos.Scoring.GetAllModels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring models: %w", err)
+	}
+
+	if err := s.refreshReferenceLists(); err != nil {
+		return nil, err
+	}
+
+	return &ScoringConfigBundle{
+		FormatVersion:  ScoringConfigFormatVersion,
+		ExportedAt:     time.Now(),
+		Models:         models,
+		ReferenceLists: s.engine.ReferenceLists(),
+		KeywordSets:    s.engine.KeywordSets(),
+	}, nil
+}
+
+// ImportScoringConfig restores a bundle in a single transaction. Models are matched by ID;
+// models missing from the bundle are left untouched. With dryRun nothing is written.
+func (s *scoringServiceImpl) ImportScoringConfig(bundle *ScoringConfigBundle, userIDStr string, dryRun bool) (*ScoringConfigImportReport, error) {
+	if bundle.FormatVersion != ScoringConfigFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d (expected %d)", bundle.FormatVersion, ScoringConfigFormatVersion)
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	for i, model := range bundle.Models {
+		if model.ID == "" {
+			return nil, fmt.Errorf("model at index %d has no ID", i)
+		}
+		if _, err := uuid.Parse(model.ID); err != nil {
+			return nil, fmt.Errorf("model %s has invalid ID: %w", model.Name, err)
+		}
+	}
+
+	report := &ScoringConfigImportReport{DryRun: dryRun, Changes: []ScoringConfigChange{}}
+
+	apply := func(repos *repository.Repositories) error {
+		existingModels, err := repos.Scoring.GetAllModels()
+		if err != nil {
+			return fmt.Errorf("failed to get scoring models: %w", err)
+		}
+		existingByID := make(map[string]scoring.ICPModel, len(existingModels))
+		for _, model := range existingModels {
+			existingByID[model.ID] = model
+		}
+
+		for _, model := range bundle.Models {
+			model := model
+			change := ScoringConfigChange{Kind: "model", Name: model.Name, ID: model.ID}
+
+			existing, exists := existingByID[model.ID]
+			switch {
+			case !exists:
+				change.Action = "create"
+				if !dryRun {
+					if model.Version < 1 {
+						model.Version = 1
+					}
+					if err := repos.Scoring.CreateModel(&model, userID); err != nil {
+						return fmt.Errorf("failed to create model %s: %w", model.Name, err)
+					}
+				}
+			case modelsEquivalent(existing, model):
+				change.Action = "unchanged"
+			default:
+				change.Action = "update"
+				if !dryRun {
+					// UpdateModel bumps the version from the stored one
+					model.Version = existing.Version
+					if err := repos.Scoring.UpdateModel(&model); err != nil {
+						return fmt.Errorf("failed to update model %s: %w", model.Name, err)
+					}
+				}
+			}
+
+			report.record(change)
+		}
+
+		if err := s.importLists(repos, listTypeReference, "reference_list", bundle.ReferenceLists, dryRun, report); err != nil {
+			return err
+		}
+		return s.importLists(repos, listTypeKeyword, "keyword_set", bundle.KeywordSets, dryRun, report)
+	}
+
+	if dryRun {
+		if err := apply(s.repos); err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+
+	if err := s.repos.Tx.WithTransaction(apply); err != nil {
+		return nil, err
+	}
+
+	// Pick up the imported lists immediately
+	if err := s.refreshReferenceLists(); err != nil {
+		s.logger.Warn("Failed to reload reference lists after import", "error", err)
+	}
+
+	return report, nil
+}
+
+// importLists compares bundle lists against the effective lists and upserts any differences.
+// Items are normalized the way the engine stores them before comparing, so re-importing
+// an export reports no changes.
+func (s *scoringServiceImpl) importLists(repos *repository.Repositories, listType, kind string, lists map[string][]string, dryRun bool, report *ScoringConfigImportReport) error {
+	stored, err := repos.Scoring.GetReferenceLists(listType)
+	if err != nil {
+		return fmt.Errorf("failed to get %s lists: %w", listType, err)
+	}
+
+	current := scoring.DefaultReferenceLists()
+	if listType == listTypeKeyword {
+		current = scoring.DefaultKeywordSets()
+	}
+	for name, items := range stored {
+		current[name] = items
+	}
+
+	names := make([]string, 0, len(lists))
+	for name := range lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		items := scoring.NormalizeListItems(lists[name])
+		change := ScoringConfigChange{Kind: kind, Name: name}
+
+		existing, exists := current[name]
+		switch {
+		case !exists:
+			change.Action = "create"
+		case reflect.DeepEqual(scoring.NormalizeListItems(existing), items):
+			change.Action = "unchanged"
+		default:
+			change.Action = "update"
+		}
+
+		if change.Action != "unchanged" && !dryRun {
+			if err := repos.Scoring.UpsertReferenceList(listType, name, items); err != nil {
+				return fmt.Errorf("failed to store %s %s: %w", kind, name, err)
+			}
+		}
+
+		report.record(change)
+	}
+
+	return nil
+}
+
+// ensureReferenceLists reloads stored list overrides when the loaded copy is older
+// than referenceListsTTL, so scoring doesn't query the lists for every company
+func (s *scoringServiceImpl) ensureReferenceLists() error {
+	s.listsMu.Lock()
+	fresh := !s.listsLoadedAt.IsZero() && time.Since(s.listsLoadedAt) < referenceListsTTL
+	s.listsMu.Unlock()
+	if fresh {
+		return nil
+	}
+	return s.refreshReferenceLists()
+}
+
+// refreshReferenceLists loads stored list overrides into the scoring engine
+func (s *scoringServiceImpl) refreshReferenceLists() error {
+	references, err := s.repos.Scoring.GetReferenceLists(listTypeReference)
+	if err != nil {
+		return fmt.Errorf("failed to get reference lists: %w", err)
+	}
+	keywords, err := s.repos.Scoring.GetReferenceLists(listTypeKeyword)
+	if err != nil {
+		return fmt.Errorf("failed to get keyword sets: %w", err)
+	}
+
+	for name, items := range references {
+		s.engine.SetReferenceList(name, items)
+	}
+	for name, items := range keywords {
+		s.engine.SetKeywordSet(name, items)
+	}
+
+	s.listsMu.Lock()
+	s.listsLoadedAt = time.Now()
+	s.listsMu.Unlock()
+	return nil
+}
+
+// record appends a change to the report and updates the counters
+func (r *ScoringConfigImportReport) record(change ScoringConfigChange) {
+	switch change.Action {
+	case "create":
+		r.Created++
+	case "update":
+		r.Updated++
+	default:
+		r.Unchanged++
+	}
+	r.Changes = append(r.Changes, change)
+}
+
+// modelsEquivalent compares the user-editable parts of two models
+func modelsEquivalent(a, b scoring.ICPModel) bool {
+	if a.Name != b.Name || a.Description != b.Description || a.IsActive != b.IsActive || a.MinScore != b.MinScore {
+		return false
+	}
+
+	// Compare rule sets through JSON so numeric types from different sources match
+	aJSON, errA := json.Marshal([]interface{}{a.Requirements, a.Exclusions, a.Rules, a.CategoryBonus})
+	bJSON, errB := json.Marshal([]interface{}{b.Requirements, b.Exclusions, b.Rules, b.CategoryBonus})
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
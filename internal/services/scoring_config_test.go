@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
+)
+
+func TestModelsEquivalent_ComparesCategoryBonus(t *testing.T) {
+	base := scoring.ICPModel{
+		Name:          "Distressed",
+		CategoryBonus: &scoring.CategoryBonus{MinCategories: 2, Points: 5},
+	}
+
+	same := base
+	same.CategoryBonus = &scoring.CategoryBonus{MinCategories: 2, Points: 5}
+	if !modelsEquivalent(base, same) {
+		t.Error("Expected models with equal category bonuses to be equivalent")
+	}
+
+	changed := base
+	changed.CategoryBonus = &scoring.CategoryBonus{MinCategories: 3, Points: 5}
+	if modelsEquivalent(base, changed) {
+		t.Error("Expected a changed category bonus to make models differ")
+	}
+
+	removed := base
+	removed.CategoryBonus = nil
+	if modelsEquivalent(base, removed) {
+		t.Error("Expected a removed category bonus to make models differ")
+	}
+}
+
+func TestImportLists_NormalizesBeforeComparing(t *testing.T) {
+	scoringRepo := NewMockScoringRepository()
+	scoringRepo.lists[listTypeReference] = map[string][]string{
+		"problematic_auditors": {"acme audit llp"},
+	}
+	repos := &repository.Repositories{Scoring: scoringRepo}
+	service := &scoringServiceImpl{repos: repos, engine: scoring.NewScoringEngine()}
+
+	report := &ScoringConfigImportReport{}
+	lists := map[string][]string{"problematic_auditors": {"  Acme Audit LLP ", ""}}
+	if err := service.importLists(repos, listTypeReference, "reference_list", lists, false, report); err != nil {
+		t.Fatalf("importLists failed: %v", err)
+	}
+
+	if report.Unchanged != 1 || report.Updated != 0 {
+		t.Errorf("Expected the list to be unchanged, got %+v", report.Changes)
+	}
+}
+
+func TestImportLists_StoresNormalizedItems(t *testing.T) {
+	scoringRepo := NewMockScoringRepository()
+	repos := &repository.Repositories{Scoring: scoringRepo}
+	service := &scoringServiceImpl{repos: repos, engine: scoring.NewScoringEngine()}
+
+	report := &ScoringConfigImportReport{}
+	lists := map[string][]string{"problematic_auditors": {" Acme Audit LLP"}}
+	if err := service.importLists(repos, listTypeReference, "reference_list", lists, false, report); err != nil {
+		t.Fatalf("importLists failed: %v", err)
+	}
+
+	stored := scoringRepo.lists[listTypeReference]["problematic_auditors"]
+	if len(stored) != 1 || stored[0] != "acme audit llp" {
+		t.Errorf("Expected normalized items to be stored, got %v", stored)
+	}
+}
+
+// countingScoringRepository counts reference list loads
+type countingScoringRepository struct {
+	*MockScoringRepository
+	listLoads int
+}
+
+func (c *countingScoringRepository) GetReferenceLists(listType string) (map[string][]string, error) {
+	c.listLoads++
+	return c.MockScoringRepository.GetReferenceLists(listType)
+}
+
+func TestEnsureReferenceLists_CachesWithinTTL(t *testing.T) {
+	scoringRepo := &countingScoringRepository{MockScoringRepository: NewMockScoringRepository()}
+	service := &scoringServiceImpl{
+		repos:  &repository.Repositories{Scoring: scoringRepo},
+		engine: scoring.NewScoringEngine(),
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := service.ensureReferenceLists(); err != nil {
+			t.Fatalf("ensureReferenceLists failed: %v", err)
+		}
+	}
+	if scoringRepo.listLoads != 2 {
+		t.Errorf("Expected one load of both list types, got %d queries", scoringRepo.listLoads)
+	}
+
+	service.listsLoadedAt = time.Now().Add(-2 * referenceListsTTL)
+	if err := service.ensureReferenceLists(); err != nil {
+		t.Fatalf("ensureReferenceLists failed: %v", err)
+	}
+	if scoringRepo.listLoads != 4 {
+		t.Errorf("Expected stale lists to be reloaded, got %d queries", scoringRepo.listLoads)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -46,6 +47,7 @@ type PipelineConfig struct {
 	MaxConcurrent       int           `json:"max_concurrent"`       // Max concurrent scoring operations
 	ProcessNewOnly      bool          `json:"process_new_only"`     // Only process companies never scored
 	RescoreOlderThanDays int          `json:"rescore_older_than_days"` // Rescore companies older than X days
+	PrioritizeRescraped bool          `json:"prioritize_rescraped"` // Rescore companies scraped since their last score first
 }
 
 // DefaultPipelineConfig returns sensible defaults
@@ -56,6 +58,7 @@ func DefaultPipelineConfig() PipelineConfig {
 		MaxConcurrent:       10,   // 10 concurrent scoring operations
 		ProcessNewOnly:      false, // Process all eligible companies
 		RescoreOlderThanDays: 7,   // Rescore companies older than 1 week
+		PrioritizeRescraped: false, // Keep never-scored then oldest-scored ordering
 	}
 }
 
@@ -104,10 +107,16 @@ func (p *ScoringPipeline) IsRunning() bool {
 	return p.isRunning
 }
 
+// Pipeline run triggers recorded in pipeline_runs
+const (
+	RunTriggerScheduled = "scheduled"
+	RunTriggerManual    = "manual"
+)
+
 // RunOnce executes a single scoring cycle manually
 func (p *ScoringPipeline) RunOnce(config PipelineConfig) (*PipelineStats, error) {
 	ctx := context.Background()
-	return p.executeScoringCycle(ctx, config)
+	return p.runCycle(ctx, config, RunTriggerManual)
 }
 
 // runPipeline is the main pipeline loop
@@ -119,7 +128,7 @@ func (p *ScoringPipeline) runPipeline(config PipelineConfig) {
 
 	// Run immediately on start
 	ctx := context.Background()
-	if stats, err := p.executeScoringCycle(ctx, config); err != nil {
+	if stats, err := p.runCycle(ctx, config, RunTriggerScheduled); err != nil {
 		log.Printf("❌ Initial scoring cycle failed: %v", err)
 	} else {
 		log.Printf("✅ Initial scoring cycle completed: %s", stats.Summary())
@@ -131,7 +140,7 @@ func (p *ScoringPipeline) runPipeline(config PipelineConfig) {
 			log.Println("📋 Pipeline stop signal received")
 			return
 		case <-ticker.C:
-			if stats, err := p.executeScoringCycle(ctx, config); err != nil {
+			if stats, err := p.runCycle(ctx, config, RunTriggerScheduled); err != nil {
 				log.Printf("❌ Scoring cycle failed: %v", err)
 			} else {
 				log.Printf("✅ Scoring cycle completed: %s", stats.Summary())
@@ -140,6 +149,76 @@ func (p *ScoringPipeline) runPipeline(config PipelineConfig) {
 	}
 }
 
+// runCycle executes a scoring cycle and records it in pipeline_runs. A failure
+// to record is logged rather than returned so history never blocks scoring.
+func (p *ScoringPipeline) runCycle(ctx context.Context, config PipelineConfig, trigger string) (*PipelineStats, error) {
+	stats, err := p.executeScoringCycle(ctx, config)
+	if recordErr := p.recordRun(ctx, stats, trigger, err); recordErr != nil {
+		log.Printf("⚠️  Failed to record pipeline run: %v", recordErr)
+	}
+	return stats, err
+}
+
+// recordRun persists one cycle's stats
+func (p *ScoringPipeline) recordRun(ctx context.Context, stats *PipelineStats, trigger string, cycleErr error) error {
+	if stats.EndTime.IsZero() {
+		stats.EndTime = time.Now()
+		stats.Duration = stats.EndTime.Sub(stats.StartTime)
+	}
+
+	errorMessage := ""
+	if cycleErr != nil {
+		errorMessage = cycleErr.Error()
+	}
+
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO pipeline_runs (
+			trigger, started_at, ended_at, duration_ms, batch_size, companies_found,
+			companies_processed, companies_succeeded, companies_failed, models_applied, error_message
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		trigger, stats.StartTime, stats.EndTime, stats.Duration.Milliseconds(), stats.BatchSize,
+		stats.CompaniesFound, stats.CompaniesProcessed, stats.CompaniesSucceeded,
+		stats.CompaniesFailed, stats.ModelsApplied, errorMessage,
+	)
+	return err
+}
+
+// GetRecentRuns returns recorded pipeline cycles, newest first, with the total count
+func (p *ScoringPipeline) GetRecentRuns(ctx context.Context, limit, offset int) ([]PipelineRun, int, error) {
+	var total int
+	if err := p.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pipeline_runs").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count pipeline runs: %w", err)
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, trigger, started_at, ended_at, duration_ms, batch_size, companies_found,
+			   companies_processed, companies_succeeded, companies_failed, models_applied, error_message
+		FROM pipeline_runs
+		ORDER BY started_at DESC
+		LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query pipeline runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []PipelineRun{}
+	for rows.Next() {
+		var run PipelineRun
+		if err := rows.Scan(
+			&run.ID, &run.Trigger, &run.StartedAt, &run.EndedAt, &run.DurationMs, &run.BatchSize,
+			&run.CompaniesFound, &run.CompaniesProcessed, &run.CompaniesSucceeded,
+			&run.CompaniesFailed, &run.ModelsApplied, &run.ErrorMessage,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan pipeline run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, total, rows.Err()
+}
+
 // executeScoringCycle performs one complete scoring cycle
 func (p *ScoringPipeline) executeScoringCycle(ctx context.Context, config PipelineConfig) (*PipelineStats, error) {
 	startTime := time.Now()
@@ -197,6 +276,7 @@ func (p *ScoringPipeline) executeScoringCycle(ctx context.Context, config Pipeli
 			stats.CompaniesSucceeded += batchStats.Succeeded
 			stats.CompaniesFailed += batchStats.Failed
 			stats.ModelsApplied += batchStats.ModelsApplied
+			stats.Results = append(stats.Results, batchStats.Results...)
 			mu.Unlock()
 
 		}(batch)
@@ -225,6 +305,40 @@ func (p *ScoringPipeline) getCompaniesForScoring(config PipelineConfig) ([]Compa
 			LIMIT $1
 		`
 		args = []interface{}{config.BatchSize * 10} // Get more than batch size for processing
+	} else if config.PrioritizeRescraped {
+		// Also pick up companies scraped since their last score, putting those
+		// with freshly changed data ahead of stale rescores
+		rescoreDate := time.Now().AddDate(0, 0, -config.RescoreOlderThanDays)
+		
+		query = `
+			WITH latest_scores AS (
+				SELECT company_id, MAX(scored_at) as last_scored
+				FROM company_scores
+				GROUP BY company_id
+			),
+			latest_scrapes AS (
+				SELECT company_id, MAX(scraped_at) as last_scraped
+				FROM company_history
+				GROUP BY company_id
+			)
+			SELECT c.id, c.ticker, c.company_name
+			FROM companies c
+			LEFT JOIN latest_scores ls ON c.id = ls.company_id
+			LEFT JOIN latest_scrapes lh ON c.id = lh.company_id
+			WHERE ls.company_id IS NULL
+			   OR ls.last_scored < $1
+			   OR lh.last_scraped > ls.last_scored
+			ORDER BY 
+				CASE
+					WHEN ls.company_id IS NULL THEN 0
+					WHEN lh.last_scraped > ls.last_scored THEN 1
+					ELSE 2
+				END,
+				CASE WHEN lh.last_scraped > ls.last_scored THEN lh.last_scraped END DESC,
+				COALESCE(ls.last_scored, c.created_at) ASC
+			LIMIT $2
+		`
+		args = []interface{}{rescoreDate, config.BatchSize * 10}
 	} else {
 		// Companies never scored OR scored longer than rescore threshold ago
 		rescoreDate := time.Now().AddDate(0, 0, -config.RescoreOlderThanDays)
@@ -271,22 +385,94 @@ func (p *ScoringPipeline) processBatch(ctx context.Context, companies []CompanyF
 	
 	for _, company := range companies {
 		stats.Processed++
+		result := CompanyCycleResult{CompanyForScoring: company, Succeeded: true}
 		
 		// Score company against all active models
-		if err := p.scoringService.ScoreCompany(company.ID); err != nil {
+		scored, err := p.scoringService.ScoreCompany(company.ID)
+		if err == nil && scored.Succeeded == 0 && scored.Failed > 0 {
+			err = fmt.Errorf("all %d models failed", scored.Failed)
+		}
+		if err != nil {
 			log.Printf("❌ Failed to score company %s (%s): %v", company.Ticker, company.ID, err)
 			stats.Failed++
+			result.Succeeded = false
+			result.Error = err.Error()
 		} else {
-			log.Printf("✅ Scored company %s (%s)", company.Ticker, company.ID)
+			if scored.Partial() {
+				log.Printf("⚠️ Company %s (%s) %s", company.Ticker, company.ID, scored.Summary())
+			} else {
+				log.Printf("✅ Scored company %s (%s)", company.Ticker, company.ID)
+			}
 			stats.Succeeded++
-			// Assuming 2 models (Double Black Diamond + Pink Market Opportunity)
-			stats.ModelsApplied += 2
+			stats.ModelsApplied += scored.Succeeded
 		}
+		stats.Results = append(stats.Results, result)
 	}
 
 	return stats
 }
 
+// BuildCycleReport collects the stored scores for every company processed in a
+// cycle, with a per-model qualification summary, for machine-readable output
+func (p *ScoringPipeline) BuildCycleReport(stats *PipelineStats) (*CycleReport, error) {
+	report := &CycleReport{
+		Stats:         stats,
+		Companies:     stats.Results,
+		Qualification: make(map[string]*ModelQualificationSummary),
+	}
+
+	if len(stats.Results) == 0 {
+		return report, nil
+	}
+
+	placeholders := make([]string, len(stats.Results))
+	args := make([]interface{}, len(stats.Results))
+	indexByID := make(map[string]int, len(stats.Results))
+	for i, result := range stats.Results {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = result.ID
+		indexByID[result.ID] = i
+	}
+
+	query := fmt.Sprintf(`
+		SELECT cs.company_id, sm.name, cs.score, COALESCE(cs.score_percent, 0), cs.qualified, COALESCE(cs.requirements_met, false)
+		FROM company_scores cs
+		JOIN scoring_models sm ON cs.scoring_model_id = sm.id
+		WHERE cs.company_id IN (%s)
+		ORDER BY sm.name
+	`, strings.Join(placeholders, ","))
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cycle scores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var companyID string
+		var score CycleModelScore
+		if err := rows.Scan(&companyID, &score.ModelName, &score.Score, &score.ScorePercent, &score.Qualified, &score.RequirementsMet); err != nil {
+			return nil, fmt.Errorf("failed to scan cycle score: %w", err)
+		}
+
+		if i, ok := indexByID[companyID]; ok {
+			report.Companies[i].Scores = append(report.Companies[i].Scores, score)
+		}
+
+		summary, exists := report.Qualification[score.ModelName]
+		if !exists {
+			summary = &ModelQualificationSummary{}
+			report.Qualification[score.ModelName] = summary
+		}
+		summary.Scored++
+		if score.Qualified {
+			summary.Qualified++
+		}
+	}
+
+	return report, rows.Err()
+}
+
 // GetStats returns current pipeline statistics
 func (p *ScoringPipeline) GetStats() (PipelineStatus, error) {
 	status := PipelineStatus{
@@ -321,22 +507,54 @@ type CompanyForScoring struct {
 }
 
 type BatchStats struct {
-	Processed     int `json:"processed"`
-	Succeeded     int `json:"succeeded"`
-	Failed        int `json:"failed"`
-	ModelsApplied int `json:"models_applied"`
+	Processed     int                  `json:"processed"`
+	Succeeded     int                  `json:"succeeded"`
+	Failed        int                  `json:"failed"`
+	ModelsApplied int                  `json:"models_applied"`
+	Results       []CompanyCycleResult `json:"results,omitempty"`
+}
+
+// CompanyCycleResult records the outcome for one company in a scoring cycle
+type CompanyCycleResult struct {
+	CompanyForScoring
+	Succeeded bool              `json:"succeeded"`
+	Error     string            `json:"error,omitempty"`
+	Scores    []CycleModelScore `json:"scores,omitempty"`
+}
+
+// CycleModelScore is a company's stored score for one model after a cycle
+type CycleModelScore struct {
+	ModelName       string  `json:"model_name"`
+	Score           int     `json:"score"`
+	ScorePercent    float64 `json:"score_percent"`
+	Qualified       bool    `json:"qualified"`
+	RequirementsMet bool    `json:"requirements_met"`
+}
+
+// ModelQualificationSummary counts scored and qualified companies for a model
+type ModelQualificationSummary struct {
+	Scored    int `json:"scored"`
+	Qualified int `json:"qualified"`
+}
+
+// CycleReport is the machine-readable result of a scoring cycle
+type CycleReport struct {
+	Stats         *PipelineStats                        `json:"stats"`
+	Companies     []CompanyCycleResult                  `json:"companies"`
+	Qualification map[string]*ModelQualificationSummary `json:"qualification"`
 }
 
 type PipelineStats struct {
-	StartTime           time.Time     `json:"start_time"`
-	EndTime             time.Time     `json:"end_time"`
-	Duration            time.Duration `json:"duration"`
-	BatchSize           int           `json:"batch_size"`
-	CompaniesFound      int           `json:"companies_found"`
-	CompaniesProcessed  int           `json:"companies_processed"`
-	CompaniesSucceeded  int           `json:"companies_succeeded"`
-	CompaniesFailed     int           `json:"companies_failed"`
-	ModelsApplied       int           `json:"models_applied"`
+	StartTime           time.Time            `json:"start_time"`
+	EndTime             time.Time            `json:"end_time"`
+	Duration            time.Duration        `json:"duration"`
+	BatchSize           int                  `json:"batch_size"`
+	CompaniesFound      int                  `json:"companies_found"`
+	CompaniesProcessed  int                  `json:"companies_processed"`
+	CompaniesSucceeded  int                  `json:"companies_succeeded"`
+	CompaniesFailed     int                  `json:"companies_failed"`
+	ModelsApplied       int                  `json:"models_applied"`
+	Results             []CompanyCycleResult `json:"-"`
 }
 
 func (s *PipelineStats) Summary() string {
@@ -344,6 +562,22 @@ func (s *PipelineStats) Summary() string {
 		s.CompaniesProcessed, s.CompaniesSucceeded, s.CompaniesFailed, s.ModelsApplied, s.Duration.Round(time.Second))
 }
 
+// PipelineRun is a recorded scoring cycle
+type PipelineRun struct {
+	ID                 string    `json:"id"`
+	Trigger            string    `json:"trigger"` // scheduled or manual
+	StartedAt          time.Time `json:"started_at"`
+	EndedAt            time.Time `json:"ended_at"`
+	DurationMs         int64     `json:"duration_ms"`
+	BatchSize          int       `json:"batch_size"`
+	CompaniesFound     int       `json:"companies_found"`
+	CompaniesProcessed int       `json:"companies_processed"`
+	CompaniesSucceeded int       `json:"companies_succeeded"`
+	CompaniesFailed    int       `json:"companies_failed"`
+	ModelsApplied      int       `json:"models_applied"`
+	ErrorMessage       string    `json:"error_message,omitempty"`
+}
+
 type PipelineStatus struct {
 	IsRunning        bool      `json:"is_running"`
 	TotalCompanies   int       `json:"total_companies"`
@@ -4,27 +4,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/errors"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/logger"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
 )
 
+// defaultModelConcurrency bounds how many models score one company in parallel
+const defaultModelConcurrency = 4
+
+// referenceListsTTL bounds how stale stored list overrides may be before scoring
+// reloads them; imports through this service reload them immediately
+const referenceListsTTL = time.Minute
+
 // scoringServiceImpl implements ScoringService
 type scoringServiceImpl struct {
-	repos  *repository.Repositories
-	engine *scoring.ScoringEngine
-	logger logger.Logger
+	repos            *repository.Repositories
+	engine           *scoring.ScoringEngine
+	logger           logger.Logger
+	modelConcurrency int
+	listsMu          sync.Mutex
+	listsLoadedAt    time.Time // when refreshReferenceLists last succeeded
 }
 
 // newScoringService creates a new scoring service implementation
 func newScoringService(repos *repository.Repositories) ScoringService {
+	return newScoringServiceWithConcurrency(repos, defaultModelConcurrency)
+}
+
+// newScoringServiceWithConcurrency creates a scoring service that scores up to
+// modelConcurrency models at once for a single company
+func newScoringServiceWithConcurrency(repos *repository.Repositories, modelConcurrency int) ScoringService {
+	if modelConcurrency <= 0 {
+		modelConcurrency = defaultModelConcurrency
+	}
+
 	return &scoringServiceImpl{
-		repos:  repos,
-		engine: scoring.NewScoringEngine(),
-		logger: logger.NewSimpleLogger(),
+		repos:            repos,
+		engine:           scoring.NewScoringEngine(),
+		logger:           logger.NewSimpleLogger(),
+		modelConcurrency: modelConcurrency,
 	}
 }
 
@@ -45,6 +70,7 @@ func (s *scoringServiceImpl) GetActiveScoringModels() ([]repository.ScoringModel
 			"must_not":       model.Exclusions,
 			"scoring_rules":  model.Rules,
 			"minimum_score":  model.MinScore,
+			"category_bonus": model.CategoryBonus,
 		}
 		rulesJSON, _ := json.Marshal(rules)
 		
@@ -75,6 +101,7 @@ func (s *scoringServiceImpl) GetScoringModel(id string) (*repository.ScoringMode
 		"must_not":       model.Exclusions,
 		"scoring_rules":  model.Rules,
 		"minimum_score":  model.MinScore,
+		"category_bonus": model.CategoryBonus,
 	}
 	rulesJSON, _ := json.Marshal(rules)
 
@@ -90,6 +117,17 @@ func (s *scoringServiceImpl) GetScoringModel(id string) (*repository.ScoringMode
 	}, nil
 }
 
+// ExplainScoringModel returns a readable summary derived from the model definition
+func (s *scoringServiceImpl) ExplainScoringModel(id string) (*scoring.ModelExplanation, error) {
+	model, err := s.repos.Scoring.GetModelByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring model: %w", err)
+	}
+
+	explanation := model.Explain()
+	return &explanation, nil
+}
+
 // CreateScoringModel creates a new scoring model
 func (s *scoringServiceImpl) CreateScoringModel(form *repository.ScoringModelForm, userIDStr string) (*repository.ScoringModel, error) {
 	userID, err := uuid.Parse(userIDStr)
@@ -156,6 +194,9 @@ func (s *scoringServiceImpl) CreateScoringModel(form *repository.ScoringModelFor
 						Weight:      getInt(itemMap, "weight"),
 						Description: getString(itemMap, "description"),
 					}
+					if _, exists := itemMap["category"]; exists {
+						rule.Category = getString(itemMap, "category")
+					}
 					model.Rules = append(model.Rules, rule)
 				}
 			}
@@ -166,6 +207,8 @@ func (s *scoringServiceImpl) CreateScoringModel(form *repository.ScoringModelFor
 		model.MinScore = getInt(map[string]interface{}{"minimum_score": minScore}, "minimum_score")
 	}
 
+	model.CategoryBonus = scoring.ParseCategoryBonus(rules["category_bonus"])
+
 	// Store in repository
 	if err := s.repos.Scoring.CreateModel(model, userID); err != nil {
 		return nil, fmt.Errorf("failed to create scoring model: %w", err)
@@ -226,38 +269,126 @@ func (s *scoringServiceImpl) DeleteScoringModel(id string) error {
 }
 
 // ScoreCompany scores a company against all active models
-func (s *scoringServiceImpl) ScoreCompany(companyID string) error {
+func (s *scoringServiceImpl) ScoreCompany(companyID string) (*CompanyScoringResult, error) {
+	var result *CompanyScoringResult
+	err := s.withCompanyLock(companyID, func() error {
+		var err error
+		result, err = s.scoreCompany(companyID)
+		return err
+	})
+	return result, err
+}
+
+// withCompanyLock serializes scoring of one company across the pipeline, manual
+// scoring calls and other processes, so score upserts never interleave
+func (s *scoringServiceImpl) withCompanyLock(companyID string, fn func() error) error {
+	return s.repos.Tx.WithAdvisoryLock(repository.LockNamespaceCompanyScoring, companyID, fn)
+}
+
+// ModelScoringOutcome records whether one model scored and stored a company
+type ModelScoringOutcome struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CompanyScoringResult reports how each active model fared when scoring a company.
+// A company can be partially scored: some models stored a score while others failed.
+type CompanyScoringResult struct {
+	CompanyID string                `json:"company_id"`
+	Models    []ModelScoringOutcome `json:"models"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+}
+
+// Partial reports whether at least one model failed
+func (r *CompanyScoringResult) Partial() bool {
+	return r.Failed > 0
+}
+
+// Summary describes the result as e.g. "scored by 3 of 4 models"
+func (r *CompanyScoringResult) Summary() string {
+	return fmt.Sprintf("scored by %d of %d models", r.Succeeded, len(r.Models))
+}
+
+// scoreCompany scores a company against all active models; callers hold the company lock
+func (s *scoringServiceImpl) scoreCompany(companyID string) (*CompanyScoringResult, error) {
 	// Get active models
 	models, err := s.repos.Scoring.GetActiveModels()
 	if err != nil {
-		return fmt.Errorf("failed to get active models: %w", err)
+		return nil, fmt.Errorf("failed to get active models: %w", err)
 	}
 
 	// Get company data
 	companyData, err := s.getCompanyData(companyID)
 	if err != nil {
-		return fmt.Errorf("failed to get company data: %w", err)
+		return nil, fmt.Errorf("failed to get company data: %w", err)
 	}
 
-	// Score against each model
-	for _, model := range models {
-		result, err := s.engine.ScoreCompany(companyData, model)
-		if err != nil {
-			log.Printf("Error scoring company %s with model %s: %v", companyID, model.Name, err)
-			continue
-		}
-		
-		result.CompanyID = companyID
-		if err := s.StoreScoreResult(companyID, s.convertScoreResult(result)); err != nil {
-			log.Printf("Error storing score result for company %s: %v", companyID, err)
-		}
+	if err := s.ensureReferenceLists(); err != nil {
+		s.logger.Warn("Using previously loaded reference lists", "error", err)
 	}
 
-	return nil
+	// Score against each model in parallel, bounded by modelConcurrency.
+	// Each goroutine writes only its own outcome slot.
+	outcomes := make([]ModelScoringOutcome, len(models))
+	semaphore := make(chan struct{}, s.modelConcurrency)
+	var wg sync.WaitGroup
+
+	for i, model := range models {
+		outcomes[i] = ModelScoringOutcome{ModelID: model.ID, ModelName: model.Name}
+
+		wg.Add(1)
+		go func(outcome *ModelScoringOutcome, model scoring.ICPModel) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := s.engine.ScoreCompany(companyData, model)
+			if err != nil {
+				log.Printf("Error scoring company %s with model %s: %v", companyID, model.Name, err)
+				outcome.Error = err.Error()
+				return
+			}
+
+			result.CompanyID = companyID
+			if err := s.StoreScoreResult(companyID, s.convertScoreResult(result)); err != nil {
+				log.Printf("Error storing score result for company %s: %v", companyID, err)
+				outcome.Error = err.Error()
+				return
+			}
+			outcome.Succeeded = true
+		}(&outcomes[i], model)
+	}
+
+	wg.Wait()
+
+	result := &CompanyScoringResult{CompanyID: companyID, Models: outcomes}
+	for _, outcome := range outcomes {
+		if outcome.Succeeded {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
 }
 
 // ScoreCompanyWithModel scores a company against a specific model
 func (s *scoringServiceImpl) ScoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error) {
+	var score *repository.CompanyScore
+	err := s.withCompanyLock(companyID, func() error {
+		var err error
+		score, err = s.scoreCompanyWithModel(companyID, modelID)
+		return err
+	})
+	return score, err
+}
+
+// scoreCompanyWithModel scores and stores one model's result; callers hold the company lock
+func (s *scoringServiceImpl) scoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error) {
 	// Get company data
 	companyData, err := s.getCompanyData(companyID)
 	if err != nil {
@@ -270,6 +401,10 @@ func (s *scoringServiceImpl) ScoreCompanyWithModel(companyID, modelID string) (*
 		return nil, fmt.Errorf("failed to get scoring model: %w", err)
 	}
 
+	if err := s.ensureReferenceLists(); err != nil {
+		s.logger.Warn("Using previously loaded reference lists", "error", err)
+	}
+
 	// Score the company
 	result, err := s.engine.ScoreCompany(companyData, *model)
 	if err != nil {
@@ -287,6 +422,45 @@ func (s *scoringServiceImpl) ScoreCompanyWithModel(companyID, modelID string) (*
 	return score, nil
 }
 
+// EvaluateCompanyWithModel scores a company against a model without storing the result.
+// Weight overrides apply to this evaluation only and are never persisted to the model.
+// When snapshotID is set the company is scored from that company_history snapshot
+// instead of its current row.
+func (s *scoringServiceImpl) EvaluateCompanyWithModel(companyID, modelID string, weightOverrides map[string]int, snapshotID string) (*scoring.ScoreResult, error) {
+	var companyData map[string]interface{}
+	var err error
+	if snapshotID != "" {
+		companyData, err = s.getSnapshotData(companyID, snapshotID)
+	} else {
+		companyData, err = s.getCompanyData(companyID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company data: %w", err)
+	}
+
+	model, err := s.repos.Scoring.GetModelByID(modelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring model: %w", err)
+	}
+
+	evaluated, err := model.WithWeightOverrides(weightOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureReferenceLists(); err != nil {
+		s.logger.Warn("Using previously loaded reference lists", "error", err)
+	}
+
+	result, err := s.engine.ScoreCompany(companyData, evaluated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to score company: %w", err)
+	}
+
+	result.CompanyID = companyID
+	return result, nil
+}
+
 // ScoreAllCompaniesWithModel scores all companies against a specific model
 func (s *scoringServiceImpl) ScoreAllCompaniesWithModel(modelID string) error {
 	// Get all company IDs
@@ -317,6 +491,26 @@ func (s *scoringServiceImpl) GetCompanyScores(companyID string) ([]repository.Co
 		return nil, fmt.Errorf("failed to get company scores: %w", err)
 	}
 
+	return toCompanyScores(companyUUID, scores), nil
+}
+
+// GetCompanyScoresPage retrieves one page of a company's scores with the total count
+func (s *scoringServiceImpl) GetCompanyScoresPage(companyID string, limit, offset int) ([]repository.CompanyScore, int, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid company ID: %w", err)
+	}
+
+	scores, total, err := s.repos.Scoring.GetScoresByCompanyPage(companyUUID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get company scores: %w", err)
+	}
+
+	return toCompanyScores(companyUUID, scores), total, nil
+}
+
+// toCompanyScores converts engine score results to their stored representation
+func toCompanyScores(companyUUID uuid.UUID, scores []scoring.ScoreResult) []repository.CompanyScore {
 	result := make([]repository.CompanyScore, len(scores))
 	for i, score := range scores {
 		breakdownJSON, _ := json.Marshal(score.Breakdown)
@@ -324,14 +518,60 @@ func (s *scoringServiceImpl) GetCompanyScores(companyID string) ([]repository.Co
 			CompanyID:       companyUUID,
 			ScoringModelID:  score.ScoringModelID,
 			Score:           score.Score,
+			ScorePercent:    score.ScorePercent,
 			Qualified:       score.Qualified,
 			RequirementsMet: score.RequirementsMet,
 			Breakdown:       string(breakdownJSON),
 			ScoredAt:        score.ScoredAt,
 		}
 	}
+	return result
+}
 
-	return result, nil
+// ScoreSummary is a compact view of a company's score for one model
+type ScoreSummary struct {
+	ModelID        string                  `json:"model_id"`
+	ModelName      string                  `json:"model_name"`
+	Total          int                     `json:"total"`
+	ScorePercent   float64                 `json:"score_percent"`
+	Qualified      bool                    `json:"qualified"`
+	TriggeredRules []scoring.TriggeredRule `json:"triggered_rules"`
+	ScoredAt       time.Time               `json:"scored_at"`
+}
+
+// GetCompanyScoreSummary returns the triggered rules for each of a company's
+// stored scores, with the top-scoring model first
+func (s *scoringServiceImpl) GetCompanyScoreSummary(companyID string) ([]ScoreSummary, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid company ID: %w", err)
+	}
+
+	scores, err := s.repos.Scoring.GetScoresByCompany(companyUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company scores: %w", err)
+	}
+
+	summaries := make([]ScoreSummary, len(scores))
+	for i, score := range scores {
+		summaries[i] = ScoreSummary{
+			ModelID:        score.ScoringModelID,
+			Total:          score.Score,
+			ScorePercent:   score.ScorePercent,
+			Qualified:      score.Qualified,
+			TriggeredRules: scoring.TriggeredRules(score.Breakdown),
+			ScoredAt:       score.ScoredAt,
+		}
+		if model, err := s.repos.Scoring.GetModelByID(score.ScoringModelID); err == nil {
+			summaries[i].ModelName = model.Name
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Total > summaries[j].Total
+	})
+
+	return summaries, nil
 }
 
 // StoreScoreResult stores a scoring result
@@ -346,13 +586,18 @@ func (s *scoringServiceImpl) StoreScoreResult(companyID string, score *repositor
 		CompanyID:       companyID,
 		ScoringModelID:  score.ScoringModelID,
 		Score:           score.Score,
+		ScorePercent:    score.ScorePercent,
 		Qualified:       score.Qualified,
 		RequirementsMet: score.RequirementsMet,
 		Breakdown:       breakdown,
 		ScoredAt:        score.ScoredAt,
 	}
 
-	if err := s.repos.Scoring.StoreScore(result); err != nil {
+	// Store the score and its risk indicator rows atomically
+	err := s.repos.Tx.WithTransaction(func(repos *repository.Repositories) error {
+		return repos.Scoring.StoreScore(result)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to store score: %w", err)
 	}
 
@@ -371,7 +616,53 @@ func (s *scoringServiceImpl) getCompanyData(companyID string) (map[string]interf
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
-	// Convert models.Company to map for scoring engine
+	lastScraped, err := s.repos.Company.GetLastScrapedAt(companyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := companyScoringData(company, lastScraped)
+
+	tierChangedAt, err := s.repos.Company.GetTierChangedAt(companyUUID)
+	if err != nil {
+		return nil, err
+	}
+	if tierChangedAt != nil {
+		data["tier_changed_at"] = *tierChangedAt
+	}
+
+	return data, nil
+}
+
+// getSnapshotData reconstructs the company data map from one company_history
+// snapshot, so a score can be reproduced from a known input
+func (s *scoringServiceImpl) getSnapshotData(companyID, snapshotID string) (map[string]interface{}, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid company ID: %w", err)
+	}
+	snapshotUUID, err := uuid.Parse(snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot ID: %w", err)
+	}
+
+	company, scrapedAt, err := s.repos.Company.GetSnapshot(companyUUID, snapshotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots stored before tier normalization hold raw text ("OTC Pink"); score
+	// them on the canonical tier, as a fresh scrape would
+	if company.MarketTierRaw == "" {
+		company.MarketTierRaw = company.MarketTier
+	}
+	company.MarketTier = models.NormalizeMarketTier(company.MarketTier)
+
+	return companyScoringData(company, &scrapedAt), nil
+}
+
+// companyScoringData converts a company to the field map the scoring engine evaluates
+func companyScoringData(company *models.Company, lastScraped *time.Time) map[string]interface{} {
 	data := map[string]interface{}{
 		"ticker":           company.Ticker,
 		"company_name":     company.CompanyName,
@@ -385,6 +676,9 @@ func (s *scoringServiceImpl) getCompanyData(companyID string) (map[string]interf
 		"transfer_agent":   company.TransferAgent,
 		"auditor":          company.Auditor,
 		"profile_verified": company.ProfileVerified,
+		"reporting_status": company.ReportingStatus,
+		"filing_dates_uncertain": company.FilingDatesUncertain,
+		"paid_promotion":   company.PaidPromotion,
 	}
 
 	if company.Last10KDate != nil {
@@ -397,7 +691,11 @@ func (s *scoringServiceImpl) getCompanyData(companyID string) (map[string]interf
 		data["last_filing_date"] = *company.LastFilingDate
 	}
 
-	return data, nil
+	if lastScraped != nil {
+		data["last_scraped_at"] = *lastScraped
+	}
+
+	return data
 }
 
 // convertScoreResult converts scoring.ScoreResult to repository.CompanyScore
@@ -409,8 +707,11 @@ func (s *scoringServiceImpl) convertScoreResult(result *scoring.ScoreResult) *re
 		CompanyID:       companyUUID,
 		ScoringModelID:  result.ScoringModelID,
 		Score:           result.Score,
+		ScorePercent:    result.ScorePercent,
 		Qualified:       result.Qualified,
 		RequirementsMet: result.RequirementsMet,
+		PointsToQualify: result.PointsToQualify,
+		FailedRequirements: result.FailedRequirements,
 		Breakdown:       string(breakdownJSON),
 		ScoredAt:        result.ScoredAt,
 	}
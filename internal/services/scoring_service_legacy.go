@@ -31,6 +31,10 @@ func (s *ScoringServiceLegacy) GetScoringModel(id string) (*repository.ScoringMo
 	return nil, fmt.Errorf("legacy method - use new service layer")
 }
 
+func (s *ScoringServiceLegacy) ExplainScoringModel(id string) (*scoring.ModelExplanation, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
+}
+
 func (s *ScoringServiceLegacy) CreateScoringModel(model *repository.ScoringModelForm, userID string) (*repository.ScoringModel, error) {
 	return nil, fmt.Errorf("legacy method - use new service layer")
 }
@@ -43,8 +47,8 @@ func (s *ScoringServiceLegacy) DeleteScoringModel(id string) error {
 	return fmt.Errorf("legacy method - use new service layer")
 }
 
-func (s *ScoringServiceLegacy) ScoreCompany(companyID string) error {
-	return fmt.Errorf("legacy method - use new service layer")
+func (s *ScoringServiceLegacy) ScoreCompany(companyID string) (*CompanyScoringResult, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
 }
 
 func (s *ScoringServiceLegacy) ScoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error) {
@@ -59,10 +63,30 @@ func (s *ScoringServiceLegacy) GetCompanyScores(companyID string) ([]repository.
 	return nil, fmt.Errorf("legacy method - use new service layer")
 }
 
+func (s *ScoringServiceLegacy) GetCompanyScoresPage(companyID string, limit, offset int) ([]repository.CompanyScore, int, error) {
+	return nil, 0, fmt.Errorf("legacy method - use new service layer")
+}
+
 func (s *ScoringServiceLegacy) StoreScoreResult(companyID string, result *repository.CompanyScore) error {
 	return fmt.Errorf("legacy method - use new service layer")
 }
 
+func (s *ScoringServiceLegacy) EvaluateCompanyWithModel(companyID, modelID string, weightOverrides map[string]int, snapshotID string) (*scoring.ScoreResult, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
+}
+
+func (s *ScoringServiceLegacy) GetCompanyScoreSummary(companyID string) ([]ScoreSummary, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
+}
+
+func (s *ScoringServiceLegacy) ExportScoringConfig() (*ScoringConfigBundle, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
+}
+
+func (s *ScoringServiceLegacy) ImportScoringConfig(bundle *ScoringConfigBundle, userID string, dryRun bool) (*ScoringConfigImportReport, error) {
+	return nil, fmt.Errorf("legacy method - use new service layer")
+}
+
 func (s *ScoringServiceLegacy) getCompanyData(companyID string) (map[string]interface{}, error) {
 	query := `
 		SELECT ticker, company_name, market_tier, quote_status, trading_volume,
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
 )
@@ -11,12 +15,14 @@ import (
 type MockScoringRepository struct {
 	models []scoring.ICPModel
 	scores map[string][]scoring.ScoreResult
+	lists  map[string]map[string][]string
 }
 
 func NewMockScoringRepository() *MockScoringRepository {
 	return &MockScoringRepository{
 		models: []scoring.ICPModel{},
 		scores: make(map[string][]scoring.ScoreResult),
+		lists:  make(map[string]map[string][]string),
 	}
 }
 
@@ -30,13 +36,17 @@ func (m *MockScoringRepository) GetActiveModels() ([]scoring.ICPModel, error) {
 	return activeModels, nil
 }
 
+func (m *MockScoringRepository) GetAllModels() ([]scoring.ICPModel, error) {
+	return m.models, nil
+}
+
 func (m *MockScoringRepository) GetModelByID(id string) (*scoring.ICPModel, error) {
 	for _, model := range m.models {
 		if model.ID == id {
 			return &model, nil
 		}
 	}
-	return nil, repository.ErrNotFound
+	return nil, fmt.Errorf("scoring model %s not found", id)
 }
 
 func (m *MockScoringRepository) CreateModel(model *scoring.ICPModel, userID uuid.UUID) error {
@@ -51,7 +61,7 @@ func (m *MockScoringRepository) UpdateModel(model *scoring.ICPModel) error {
 			return nil
 		}
 	}
-	return repository.ErrNotFound
+	return fmt.Errorf("scoring model %s not found", model.ID)
 }
 
 func (m *MockScoringRepository) DeleteModel(id string) error {
@@ -61,7 +71,7 @@ func (m *MockScoringRepository) DeleteModel(id string) error {
 			return nil
 		}
 	}
-	return repository.ErrNotFound
+	return fmt.Errorf("scoring model %s not found", id)
 }
 
 func (m *MockScoringRepository) StoreScore(score *scoring.ScoreResult) error {
@@ -89,6 +99,20 @@ func (m *MockScoringRepository) GetScoresByCompany(companyID uuid.UUID) ([]scori
 	return m.scores[companyID.String()], nil
 }
 
+func (m *MockScoringRepository) GetScoresByCompanyPage(companyID uuid.UUID, limit, offset int) ([]scoring.ScoreResult, int, error) {
+	scores := m.scores[companyID.String()]
+	total := len(scores)
+	if offset > total {
+		offset = total
+	}
+	if end := offset + limit; end < total {
+		scores = scores[offset:end]
+	} else {
+		scores = scores[offset:]
+	}
+	return scores, total, nil
+}
+
 func (m *MockScoringRepository) GetScoresByModel(modelID string) ([]scoring.ScoreResult, error) {
 	var results []scoring.ScoreResult
 	for _, companyScores := range m.scores {
@@ -119,13 +143,138 @@ func (m *MockScoringRepository) DeleteScoresByModel(modelID string) error {
 	return nil
 }
 
+func (m *MockScoringRepository) GetReferenceLists(listType string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for name, items := range m.lists[listType] {
+		result[name] = items
+	}
+	return result, nil
+}
+
+func (m *MockScoringRepository) UpsertReferenceList(listType, name string, items []string) error {
+	if m.lists[listType] == nil {
+		m.lists[listType] = make(map[string][]string)
+	}
+	m.lists[listType][name] = items
+	return nil
+}
+
+// MockCompanyRepository implements CompanyRepository for testing
+type MockCompanyRepository struct {
+	companies map[uuid.UUID]*models.Company
+	snapshots map[uuid.UUID]*models.Company
+}
+
+func (m *MockCompanyRepository) GetByID(id uuid.UUID) (*models.Company, error) {
+	if company, ok := m.companies[id]; ok {
+		return company, nil
+	}
+	return nil, fmt.Errorf("company not found")
+}
+
+func (m *MockCompanyRepository) GetByTicker(ticker string) (*models.Company, error) {
+	for _, company := range m.companies {
+		if company.Ticker == ticker {
+			return company, nil
+		}
+	}
+	return nil, fmt.Errorf("company with ticker %s not found", ticker)
+}
+
+func (m *MockCompanyRepository) Create(company *models.Company) error {
+	if m.companies == nil {
+		m.companies = make(map[uuid.UUID]*models.Company)
+	}
+	m.companies[company.ID] = company
+	return nil
+}
+
+func (m *MockCompanyRepository) Update(company *models.Company) error {
+	if _, ok := m.companies[company.ID]; !ok {
+		return fmt.Errorf("company not found")
+	}
+	m.companies[company.ID] = company
+	return nil
+}
+
+func (m *MockCompanyRepository) Delete(id uuid.UUID) error {
+	delete(m.companies, id)
+	return nil
+}
+
+func (m *MockCompanyRepository) GetAll(filters repository.CompanyFilters) ([]models.Company, error) {
+	var companies []models.Company
+	for _, company := range m.companies {
+		companies = append(companies, *company)
+	}
+	return companies, nil
+}
+
+func (m *MockCompanyRepository) GetUnscored(criteria repository.UnscoredCriteria) ([]models.Company, error) {
+	return m.GetAll(repository.CompanyFilters{})
+}
+
+func (m *MockCompanyRepository) GetAllIDs() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id := range m.companies {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (m *MockCompanyRepository) GetLastScrapedAt(id uuid.UUID) (*time.Time, error) {
+	return nil, nil
+}
+
+func (m *MockCompanyRepository) GetTierChangedAt(id uuid.UUID) (*time.Time, error) {
+	return nil, nil
+}
+
+func (m *MockCompanyRepository) GetSnapshot(companyID, snapshotID uuid.UUID) (*models.Company, time.Time, error) {
+	if snapshot, ok := m.snapshots[snapshotID]; ok && snapshot.ID == companyID {
+		copied := *snapshot
+		return &copied, time.Time{}, nil
+	}
+	return nil, time.Time{}, fmt.Errorf("snapshot %s not found", snapshotID)
+}
+
+// MockUserRepository implements UserRepository for testing
+type MockUserRepository struct{}
+
+func (m *MockUserRepository) GetByID(id uuid.UUID) (*models.User, error) {
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
+	return nil, fmt.Errorf("user with email %s not found", email)
+}
+
+func (m *MockUserRepository) Create(user *models.User) error { return nil }
+
+func (m *MockUserRepository) Update(user *models.User) error { return nil }
+
+func (m *MockUserRepository) Delete(id uuid.UUID) error { return nil }
+
+// MockTransactionManager runs functions directly against the mock repositories
+type MockTransactionManager struct {
+	repos *repository.Repositories
+}
+
+func (m *MockTransactionManager) WithTransaction(fn func(repos *repository.Repositories) error) error {
+	return fn(m.repos)
+}
+
+func (m *MockTransactionManager) WithAdvisoryLock(namespace int32, key string, fn func() error) error {
+	return fn()
+}
+
 // Test example
 func TestScoringService_GetActiveScoringModels(t *testing.T) {
 	// Setup mocks
 	mockScoringRepo := NewMockScoringRepository()
-	mockCompanyRepo := &MockCompanyRepository{} // Would need to implement
-	mockUserRepo := &MockUserRepository{}       // Would need to implement
-	mockTxManager := &MockTransactionManager{}  // Would need to implement
+	mockCompanyRepo := &MockCompanyRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockTxManager := &MockTransactionManager{}
 
 	repos := &repository.Repositories{
 		Scoring: mockScoringRepo,
@@ -133,6 +282,7 @@ func TestScoringService_GetActiveScoringModels(t *testing.T) {
 		User:    mockUserRepo,
 		Tx:      mockTxManager,
 	}
+	mockTxManager.repos = repos
 
 	// Add test data
 	testModel := scoring.ICPModel{
@@ -195,4 +345,21 @@ func BenchmarkScoringService_GetActiveScoringModels(b *testing.B) {
 			b.Fatalf("Unexpected error: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestScoringService_SnapshotDataUsesCanonicalMarketTier(t *testing.T) {
+	companyID := uuid.New()
+	snapshotID := uuid.New()
+	companyRepo := &MockCompanyRepository{snapshots: map[uuid.UUID]*models.Company{
+		snapshotID: {ID: companyID, Ticker: "ABCD", MarketTier: "Pink Limited Information"},
+	}}
+	service := &scoringServiceImpl{repos: &repository.Repositories{Company: companyRepo}}
+
+	data, err := service.getSnapshotData(companyID.String(), snapshotID.String())
+	if err != nil {
+		t.Fatalf("getSnapshotData failed: %v", err)
+	}
+	if data["market_tier"] != models.MarketTierPinkLimited {
+		t.Errorf("Expected snapshot market tier %q, got %v", models.MarketTierPinkLimited, data["market_tier"])
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/models"
 	"github.com/ajharbinger/otc-oxy2-pipeline/internal/repository"
+	"github.com/ajharbinger/otc-oxy2-pipeline/internal/scoring"
 	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
 )
 
@@ -13,6 +14,9 @@ type Services struct {
 	Company CompanyService
 	Scoring ScoringService
 	Auth    AuthService
+	// RequirementsSweeper rescores stored scores with unknown requirements_met;
+	// callers start it when Enabled
+	RequirementsSweeper *RequirementsSweeper
 }
 
 // CompanyService defines the interface for company business logic
@@ -34,13 +38,21 @@ type ScoringService interface {
 	CreateScoringModel(model *repository.ScoringModelForm, userID string) (*repository.ScoringModel, error)
 	UpdateScoringModel(id string, model *repository.ScoringModelForm) error
 	DeleteScoringModel(id string) error
+	ExplainScoringModel(id string) (*scoring.ModelExplanation, error)
 
 	// Scoring operations
-	ScoreCompany(companyID string) error
+	ScoreCompany(companyID string) (*CompanyScoringResult, error)
 	ScoreCompanyWithModel(companyID, modelID string) (*repository.CompanyScore, error)
 	ScoreAllCompaniesWithModel(modelID string) error
 	GetCompanyScores(companyID string) ([]repository.CompanyScore, error)
+	GetCompanyScoresPage(companyID string, limit, offset int) ([]repository.CompanyScore, int, error)
 	StoreScoreResult(companyID string, result *repository.CompanyScore) error
+	EvaluateCompanyWithModel(companyID, modelID string, weightOverrides map[string]int, snapshotID string) (*scoring.ScoreResult, error)
+	GetCompanyScoreSummary(companyID string) ([]ScoreSummary, error)
+
+	// Configuration snapshot and restore
+	ExportScoringConfig() (*ScoringConfigBundle, error)
+	ImportScoringConfig(bundle *ScoringConfigBundle, userID string, dryRun bool) (*ScoringConfigImportReport, error)
 }
 
 // AuthService defines the interface for authentication business logic
@@ -55,10 +67,13 @@ type AuthService interface {
 func NewServices(db *sql.DB, cfg *config.Config) *Services {
 	repos := repository.NewRepositories(db)
 	
+	scoringService := newScoringServiceWithConcurrency(repos, cfg.ScoringModelConcurrency)
+
 	return &Services{
-		Company: newCompanyService(repos),
-		Scoring: newScoringService(repos),
-		Auth:    newAuthService(repos, cfg),
+		Company:             newCompanyService(repos),
+		Scoring:             scoringService,
+		Auth:                newAuthService(repos, cfg),
+		RequirementsSweeper: NewRequirementsSweeper(db, scoringService, cfg),
 	}
 }
 
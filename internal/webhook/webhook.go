@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+	"github.com/google/uuid"
+)
+
+// Event types delivered to webhook subscribers
+const (
+	EventScrapeJobCompleted = "scrape_job.completed"
+	EventScrapeJobFailed    = "scrape_job.failed"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body when a secret is configured
+const SignatureHeader = "X-Webhook-Signature"
+
+// maxAttempts bounds delivery retries per URL
+const maxAttempts = 3
+
+// Event is the JSON payload posted to each webhook URL
+type Event struct {
+	ID         uuid.UUID   `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Dispatcher posts events to the configured webhook URLs
+type Dispatcher struct {
+	urls       []string
+	secret     string
+	httpClient *http.Client
+	retryDelay time.Duration
+}
+
+// NewDispatcher creates a dispatcher from configuration. With no WEBHOOK_URLS
+// configured, events are dropped.
+func NewDispatcher(cfg *config.Config) *Dispatcher {
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Dispatcher{
+		urls:       cfg.GetWebhookURLs(),
+		secret:     cfg.WebhookSecret,
+		httpClient: &http.Client{Timeout: timeout},
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// Enabled reports whether any webhook URL is configured
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && len(d.urls) > 0
+}
+
+// Publish delivers an event in the background so callers are never blocked by
+// slow subscribers. Delivery failures are logged.
+func (d *Dispatcher) Publish(eventType string, data interface{}) {
+	if !d.Enabled() {
+		return
+	}
+
+	event := Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := d.Send(ctx, event); err != nil {
+			log.Printf("⚠️  Webhook delivery of %s failed: %v", event.Type, err)
+		}
+	}()
+}
+
+// Send delivers an event to every configured URL, retrying transient failures
+func (d *Dispatcher) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	var failed []string
+	for _, url := range d.urls {
+		if err := d.deliver(ctx, url, event.Type, body); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", url, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d deliveries failed: %s", len(failed), len(d.urls), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// deliver posts the body to a single URL. Client errors (4xx) are not retried.
+func (d *Dispatcher) deliver(ctx context.Context, url, eventType string, body []byte) error {
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.retryDelay * time.Duration(attempt-1)):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Webhook-Event", eventType)
+		if d.secret != "" {
+			req.Header.Set(SignatureHeader, Sign(d.secret, body))
+		}
+
+		resp, err := d.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("status %d", resp.StatusCode)
+		if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// Sign returns the hex HMAC-SHA256 of body, which receivers can use to verify the sender
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ajharbinger/otc-oxy2-pipeline/pkg/config"
+)
+
+func TestDispatcher_SendSignsPayload(t *testing.T) {
+	var received Event
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		if signature != Sign("secret", body) {
+			t.Errorf("signature mismatch")
+		}
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(&config.Config{WebhookURLs: server.URL, WebhookSecret: "secret"})
+	event := Event{Type: EventScrapeJobCompleted, OccurredAt: time.Now(), Data: map[string]int{"processed_tickers": 3}}
+
+	if err := d.Send(context.Background(), event); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if received.Type != EventScrapeJobCompleted {
+		t.Errorf("Expected event type %s, got %s", EventScrapeJobCompleted, received.Type)
+	}
+	if signature == "" {
+		t.Errorf("Expected signature header to be set")
+	}
+}
+
+func TestDispatcher_RetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < maxAttempts {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(&config.Config{WebhookURLs: server.URL})
+	d.retryDelay = time.Millisecond
+
+	if err := d.Send(context.Background(), Event{Type: EventScrapeJobFailed}); err != nil {
+		t.Fatalf("Expected delivery after retries, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != maxAttempts {
+		t.Errorf("Expected %d attempts, got %d", maxAttempts, got)
+	}
+}
+
+func TestDispatcher_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(&config.Config{WebhookURLs: server.URL})
+	d.retryDelay = time.Millisecond
+
+	if err := d.Send(context.Background(), Event{Type: EventScrapeJobCompleted}); err == nil {
+		t.Fatal("Expected an error for a 404 response")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestDispatcher_DisabledWithoutURLs(t *testing.T) {
+	d := NewDispatcher(&config.Config{})
+	if d.Enabled() {
+		t.Error("Expected dispatcher without URLs to be disabled")
+	}
+}
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_scoring_reference_lists_updated_at ON scoring_reference_lists;
+DROP TABLE IF EXISTS scoring_reference_lists;
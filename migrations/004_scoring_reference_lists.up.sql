@@ -0,0 +1,13 @@
+-- Reference lists and keyword sets used by computed scoring fields.
+-- Rows override the built-in defaults in the scoring engine.
+CREATE TABLE scoring_reference_lists (
+    list_type VARCHAR(20) NOT NULL CHECK (list_type IN ('reference', 'keyword')),
+    name VARCHAR(100) NOT NULL,
+    items JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_type, name)
+);
+
+CREATE TRIGGER update_scoring_reference_lists_updated_at BEFORE UPDATE ON scoring_reference_lists
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_company_scores_score_percent;
+
+ALTER TABLE company_scores
+DROP COLUMN IF EXISTS score_percent;
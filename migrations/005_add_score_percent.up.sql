@@ -0,0 +1,6 @@
+-- Normalized 0-100 score relative to the model's max achievable points.
+-- Left NULL for scores recorded before this column existed.
+ALTER TABLE company_scores
+ADD COLUMN score_percent NUMERIC(5,1);
+
+CREATE INDEX idx_company_scores_score_percent ON company_scores(score_percent DESC);
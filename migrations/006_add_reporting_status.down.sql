@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_companies_reporting_status;
+
+ALTER TABLE companies
+DROP COLUMN IF EXISTS reporting_status;
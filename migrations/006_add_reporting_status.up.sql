@@ -0,0 +1,6 @@
+-- Reporting standard parsed from the disclosure page (sec_reporting, alternative_reporting,
+-- bank_reporting, international_reporting, non_reporting). Empty when not yet known.
+ALTER TABLE companies
+ADD COLUMN reporting_status VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_companies_reporting_status ON companies(reporting_status);
@@ -0,0 +1,3 @@
+ALTER TABLE companies
+DROP COLUMN IF EXISTS filing_dates_uncertain,
+DROP COLUMN IF EXISTS filing_dates_missing_scrapes;
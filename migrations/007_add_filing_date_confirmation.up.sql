@@ -0,0 +1,5 @@
+-- Consecutive scrapes in which 10-K/10-Q dates could not be extracted, and whether
+-- the resulting delinquency is still unconfirmed (see DELINQUENCY_CONFIRMATION_SCRAPES).
+ALTER TABLE companies
+ADD COLUMN filing_dates_missing_scrapes INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN filing_dates_uncertain BOOLEAN NOT NULL DEFAULT false;
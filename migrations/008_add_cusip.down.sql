@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_companies_cusip;
+
+ALTER TABLE companies
+DROP COLUMN IF EXISTS cusip;
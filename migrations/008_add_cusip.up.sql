@@ -0,0 +1,5 @@
+-- CUSIP security identifier; used as a secondary match key when a ticker changes.
+ALTER TABLE companies
+ADD COLUMN cusip VARCHAR(9) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_companies_cusip ON companies(cusip) WHERE cusip <> '';
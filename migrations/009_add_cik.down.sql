@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_companies_cik;
+
+ALTER TABLE companies
+DROP COLUMN IF EXISTS cik;
@@ -0,0 +1,5 @@
+-- SEC Central Index Key (10-digit, zero-padded); set when a company is imported by CIK.
+ALTER TABLE companies
+ADD COLUMN cik VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_companies_cik ON companies(cik) WHERE cik <> '';
@@ -0,0 +1,4 @@
+ALTER TABLE company_scores
+DROP COLUMN IF EXISTS insights_refreshed_at,
+DROP COLUMN IF EXISTS insights_version,
+DROP COLUMN IF EXISTS insights;
@@ -0,0 +1,7 @@
+-- Persisted business insights snapshot for each score, refreshed in bulk so
+-- exports and stats can use a consistent version of the insight logic.
+-- Left NULL until the first refresh; readers fall back to live computation.
+ALTER TABLE company_scores
+ADD COLUMN insights JSONB,
+ADD COLUMN insights_version INTEGER,
+ADD COLUMN insights_refreshed_at TIMESTAMP;
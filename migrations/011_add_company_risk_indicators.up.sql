@@ -0,0 +1,21 @@
+-- Normalized index of triggered scoring rules ("risk indicators") per score,
+-- replaced whenever a company is re-scored against a model
+CREATE TABLE company_risk_indicators (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    scoring_model_id UUID NOT NULL REFERENCES scoring_models(id) ON DELETE CASCADE,
+    indicator VARCHAR(100) NOT NULL,
+    points INTEGER NOT NULL,
+    PRIMARY KEY (company_id, scoring_model_id, indicator)
+);
+
+CREATE INDEX idx_company_risk_indicators_indicator ON company_risk_indicators(indicator, scoring_model_id);
+
+-- Backfill from existing score breakdowns
+INSERT INTO company_risk_indicators (company_id, scoring_model_id, indicator, points)
+SELECT cs.company_id, cs.scoring_model_id, b.key, (b.value->>'points')::INTEGER
+FROM company_scores cs
+CROSS JOIN LATERAL jsonb_each(cs.score_breakdown) b
+WHERE cs.company_id IS NOT NULL
+  AND cs.scoring_model_id IS NOT NULL
+  AND (b.value->>'triggered')::BOOLEAN
+  AND (b.value->>'points')::INTEGER > 0;
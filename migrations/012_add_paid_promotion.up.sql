@@ -0,0 +1,3 @@
+-- Set when disclosure or overview text mentions paid stock promotion
+ALTER TABLE companies
+ADD COLUMN paid_promotion BOOLEAN NOT NULL DEFAULT false;
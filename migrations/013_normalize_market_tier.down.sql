@@ -0,0 +1,10 @@
+UPDATE scoring_models
+SET rules = replace(replace(rules::text,
+        '"value": "ExpertMarket"', '"value": "Expert Market"'),
+        '"value": "PinkCurrent"', '"value": "OTC Pink"')::jsonb
+WHERE rules::text LIKE '%"value": "ExpertMarket"%' OR rules::text LIKE '%"value": "PinkCurrent"%';
+
+UPDATE companies SET market_tier = market_tier_raw WHERE market_tier_raw <> '';
+
+ALTER TABLE companies
+DROP COLUMN IF EXISTS market_tier_raw;
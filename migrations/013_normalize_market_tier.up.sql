@@ -0,0 +1,24 @@
+-- Keep scraped tier text in market_tier_raw; market_tier holds the canonical tier
+ALTER TABLE companies
+ADD COLUMN market_tier_raw VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE companies SET market_tier_raw = market_tier WHERE market_tier IS NOT NULL;
+
+UPDATE companies SET market_tier = CASE
+    WHEN market_tier ~* 'expert\s*market' THEN 'ExpertMarket'
+    WHEN market_tier ~* 'gr[ae]y\s*market' THEN 'GreyMarket'
+    WHEN market_tier ~* 'pink\s*no\s*information' THEN 'ExpertMarket'
+    WHEN market_tier ~* 'pink\s*limited' THEN 'PinkLimited'
+    WHEN market_tier ~* 'otcqx' THEN 'OTCQX'
+    WHEN market_tier ~* 'otcqb|venture\s*market' THEN 'OTCQB'
+    WHEN market_tier ~* 'pink' THEN 'PinkCurrent'
+    ELSE ''
+END
+WHERE market_tier IS NOT NULL;
+
+-- Point stored model requirements at the canonical values
+UPDATE scoring_models
+SET rules = replace(replace(rules::text,
+        '"value": "Expert Market"', '"value": "ExpertMarket"'),
+        '"value": "OTC Pink"', '"value": "PinkCurrent"')::jsonb
+WHERE rules::text LIKE '%"value": "Expert Market"%' OR rules::text LIKE '%"value": "OTC Pink"%';
@@ -0,0 +1,17 @@
+-- One row per scoring pipeline cycle, for throughput history
+CREATE TABLE pipeline_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trigger VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    batch_size INTEGER NOT NULL,
+    companies_found INTEGER NOT NULL DEFAULT 0,
+    companies_processed INTEGER NOT NULL DEFAULT 0,
+    companies_succeeded INTEGER NOT NULL DEFAULT 0,
+    companies_failed INTEGER NOT NULL DEFAULT 0,
+    models_applied INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_pipeline_runs_started_at ON pipeline_runs(started_at DESC);
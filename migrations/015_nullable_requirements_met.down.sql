@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_company_scores_requirements_unknown;
+
+UPDATE company_scores SET requirements_met = false WHERE requirements_met IS NULL;
+
+ALTER TABLE company_scores ALTER COLUMN requirements_met SET DEFAULT false;
+ALTER TABLE company_scores ALTER COLUMN requirements_met SET NOT NULL;
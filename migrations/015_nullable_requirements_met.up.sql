@@ -0,0 +1,10 @@
+-- requirements_met was backfilled from a score threshold when it was added, so
+-- stored values can't be trusted until the engine recomputes them. NULL marks a
+-- score whose requirements are unknown; the requirements sweeper rescores those.
+ALTER TABLE company_scores ALTER COLUMN requirements_met DROP NOT NULL;
+ALTER TABLE company_scores ALTER COLUMN requirements_met DROP DEFAULT;
+
+UPDATE company_scores SET requirements_met = NULL;
+
+CREATE INDEX idx_company_scores_requirements_unknown ON company_scores(scored_at)
+    WHERE requirements_met IS NULL;
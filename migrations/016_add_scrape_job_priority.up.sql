@@ -0,0 +1,2 @@
+ALTER TABLE scrape_jobs ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+    CHECK (priority IN ('high', 'normal', 'low'));
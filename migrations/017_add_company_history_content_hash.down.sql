@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_company_history_company_scraped;
+
+ALTER TABLE company_history DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE company_history DROP COLUMN IF EXISTS content_hash;
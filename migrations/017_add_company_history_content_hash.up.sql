@@ -0,0 +1,6 @@
+-- content_hash identifies unchanged rescrapes so they don't store a duplicate
+-- snapshot; last_seen_at records when that unchanged content was last scraped
+ALTER TABLE company_history ADD COLUMN content_hash VARCHAR(64);
+ALTER TABLE company_history ADD COLUMN last_seen_at TIMESTAMP;
+
+CREATE INDEX idx_company_history_company_scraped ON company_history(company_id, scraped_at DESC);
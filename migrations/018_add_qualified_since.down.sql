@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_company_scores_qualified_since;
+
+ALTER TABLE company_scores DROP COLUMN IF EXISTS qualified_since;
@@ -0,0 +1,6 @@
+-- When each score last became qualified, maintained by the score upsert. Scores
+-- already qualified before this column existed keep NULL: their start date is unknown.
+ALTER TABLE company_scores ADD COLUMN qualified_since TIMESTAMP;
+
+CREATE INDEX idx_company_scores_qualified_since ON company_scores(qualified_since)
+    WHERE qualified_since IS NOT NULL;
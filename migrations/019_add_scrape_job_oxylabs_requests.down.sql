@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_scrape_jobs_started_at;
+
+ALTER TABLE scrape_jobs DROP COLUMN IF EXISTS oxylabs_requests;
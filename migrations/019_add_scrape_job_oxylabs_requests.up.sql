@@ -0,0 +1,4 @@
+-- OxyLabs page requests (credits) consumed by each job, for cost reporting
+ALTER TABLE scrape_jobs ADD COLUMN oxylabs_requests INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_scrape_jobs_started_at ON scrape_jobs(started_at);
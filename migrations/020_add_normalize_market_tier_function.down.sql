@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS normalize_market_tier(TEXT);
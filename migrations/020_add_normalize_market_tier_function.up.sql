@@ -0,0 +1,16 @@
+-- Maps raw tier text to its canonical tier, or '' when unrecognized. Mirrors migration 013
+-- and models.NormalizeMarketTier so snapshots stored before normalization compare
+-- correctly against companies.market_tier.
+CREATE OR REPLACE FUNCTION normalize_market_tier(raw TEXT)
+RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN raw ~* 'expert\s*market' THEN 'ExpertMarket'
+        WHEN raw ~* 'gr[ae]y\s*market' THEN 'GreyMarket'
+        WHEN raw ~* 'pink\s*no\s*information' THEN 'ExpertMarket'
+        WHEN raw ~* 'pink\s*limited' THEN 'PinkLimited'
+        WHEN raw ~* 'otcqx' THEN 'OTCQX'
+        WHEN raw ~* 'otcqb|venture\s*market' THEN 'OTCQB'
+        WHEN raw ~* 'pink' THEN 'PinkCurrent'
+        ELSE ''
+    END
+$$ LANGUAGE sql IMMUTABLE;
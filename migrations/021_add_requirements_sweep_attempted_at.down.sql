@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_company_scores_requirements_unknown;
+
+ALTER TABLE company_scores DROP COLUMN IF EXISTS requirements_sweep_attempted_at;
+
+CREATE INDEX idx_company_scores_requirements_unknown ON company_scores(scored_at)
+    WHERE requirements_met IS NULL;
@@ -0,0 +1,9 @@
+-- When the requirements sweeper last failed to rescore each score. The sweeper works
+-- through never-attempted scores first, so rows that keep failing don't block the batch.
+ALTER TABLE company_scores ADD COLUMN requirements_sweep_attempted_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_company_scores_requirements_unknown;
+
+CREATE INDEX idx_company_scores_requirements_unknown
+    ON company_scores(requirements_sweep_attempted_at NULLS FIRST, scored_at)
+    WHERE requirements_met IS NULL;
@@ -0,0 +1,11 @@
+-- OxyLabs requests made outside scrape jobs (single-ticker rescrapes and parse-URL
+-- previews), so credit usage reports cover every request
+CREATE TABLE oxylabs_usage (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source VARCHAR(50) NOT NULL,
+    target VARCHAR(500) NOT NULL DEFAULT '',
+    requests INTEGER NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_oxylabs_usage_recorded_at ON oxylabs_usage(recorded_at);
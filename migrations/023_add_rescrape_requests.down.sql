@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rescrape_requests;
@@ -0,0 +1,7 @@
+-- Last on-demand rescrape request per ticker. The rescrape endpoint claims a
+-- ticker by conditionally upserting its row, so the cooldown holds across server
+-- instances and applies whether or not the scrape succeeds
+CREATE TABLE rescrape_requests (
+    ticker VARCHAR(10) PRIMARY KEY,
+    last_requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -10,17 +10,63 @@ import (
 type Config struct {
 	DatabaseURL       string
 	JWTSecret        string
+	JWTKeyID         string // Key ID stamped on issued tokens ("kid" header)
+	JWTPreviousKeys  string // Comma-separated kid:secret pairs still accepted for verification
 	Port             string
 	Environment      string
+	// HTTP server timeouts; the write timeout must cover the slowest export or upload
+	// and the synchronous rescrape (2 min) and parse-URL (3 min) requests
+	HTTPReadTimeoutSeconds  int
+	HTTPWriteTimeoutSeconds int
+	HTTPIdleTimeoutSeconds  int
 	DropContactAPIKey string
 	OxyLabsUsername   string
 	OxyLabsPassword   string
 	OxyLabsEndpoint   string
+	SECUserAgent      string // Required by SEC for EDGAR requests, e.g. "Company Name admin@example.com"
 	// Security configuration
 	AllowedOrigins    string
 	TrustedProxies    string
 	EnableRateLimit   bool
 	MaxRequestSize    int64
+	AllowedContentTypes string // Comma-separated default POST/PUT content types (routes may override)
+	// Database connection pool (0 = use database package defaults).
+	// Size DBMaxOpenConns above the pipeline's MaxConcurrent to avoid pool waits.
+	DBMaxOpenConns           int
+	DBMaxIdleConns           int
+	DBConnMaxLifetimeMinutes int
+	// Extra raw-text-to-tier aliases for market tier normalization, e.g.
+	// "pink sheets=PinkCurrent,caveat emptor tier=ExpertMarket"
+	MarketTierAliases string
+	// Fallback score threshold for include_required_only lead filters when a
+	// model has no stored minimum_score
+	LeadRequiredMinScore int
+	// Max models scored in parallel for a single company
+	ScoringModelConcurrency int
+	// Consecutive scrapes without extractable 10-K/10-Q dates before a company
+	// is scored as delinquent; earlier scrapes mark delinquency as uncertain
+	DelinquencyConfirmationScrapes int
+	// Background rescoring of scores stored without requirements_met (0 minutes = disabled)
+	RequirementsSweepIntervalMinutes int
+	RequirementsSweepBatchSize       int
+	// History retention (0 days = keep everything)
+	HistoryRetentionDays   int
+	RetentionIntervalHours int
+	// Minimum minutes between on-demand rescrapes of the same ticker
+	RescrapeCooldownMinutes int
+	// Identical snapshots within this many hours of the latest are not stored again (0 = always store)
+	SnapshotDedupeWindowHours int
+	// Max tickers per scrape job, by the submitting user's role
+	MaxJobTickersAdmin int
+	MaxJobTickersUser  int
+	// URL-level fan-out inside OxyLabs GetBatch, tuned independently of
+	// ticker-level scraper concurrency
+	OxyLabsBatchConcurrency      int
+	OxyLabsRequestTimeoutSeconds int
+	// Webhook notifications (comma-separated URLs; empty disables them)
+	WebhookURLs           string
+	WebhookSecret         string
+	WebhookTimeoutSeconds int
 }
 
 // New creates a new configuration instance from environment variables
@@ -28,17 +74,56 @@ func New() *Config {
 	return &Config{
 		DatabaseURL:       getEnv("DATABASE_URL", ""),
 		JWTSecret:        getEnv("JWT_SECRET", ""),
+		JWTKeyID:         getEnv("JWT_KEY_ID", ""),
+		JWTPreviousKeys:  getEnv("JWT_PREVIOUS_KEYS", ""),
 		Port:             getEnv("PORT", "8080"),
 		Environment:      getEnv("ENV", "development"),
+		// HTTP server timeouts
+		HTTPReadTimeoutSeconds:  getEnvAsInt("HTTP_READ_TIMEOUT_SECONDS", 30),
+		HTTPWriteTimeoutSeconds: getEnvAsInt("HTTP_WRITE_TIMEOUT_SECONDS", 240),
+		HTTPIdleTimeoutSeconds:  getEnvAsInt("HTTP_IDLE_TIMEOUT_SECONDS", 120),
 		DropContactAPIKey: getEnv("DROPCONTACT_API_KEY", ""),
 		OxyLabsUsername:   getEnv("OXYLABS_USERNAME", ""),
 		OxyLabsPassword:   getEnv("OXYLABS_PASSWORD", ""),
 		OxyLabsEndpoint:   getEnv("OXYLABS_ENDPOINT", "https://realtime.oxylabs.io/v1/queries"),
+		SECUserAgent:      getEnv("SEC_USER_AGENT", ""),
 		// Security configuration
 		AllowedOrigins:    getEnv("ALLOWED_ORIGINS", ""),
 		TrustedProxies:    getEnv("TRUSTED_PROXIES", ""),
 		EnableRateLimit:   getEnv("ENABLE_RATE_LIMIT", "true") == "true",
 		MaxRequestSize:    getEnvAsInt64("MAX_REQUEST_SIZE", 10*1024*1024), // 10MB default
+		AllowedContentTypes: getEnv("ALLOWED_CONTENT_TYPES", ""),
+		// Database connection pool
+		DBMaxOpenConns:           getEnvAsInt("DB_MAX_OPEN_CONNS", 0),
+		DBMaxIdleConns:           getEnvAsInt("DB_MAX_IDLE_CONNS", 0),
+		DBConnMaxLifetimeMinutes: getEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 0),
+		// Market tier normalization
+		MarketTierAliases: getEnv("MARKET_TIER_ALIASES", ""),
+		// Lead export
+		LeadRequiredMinScore: getEnvAsInt("LEAD_REQUIRED_MIN_SCORE", 3),
+		// Scoring
+		ScoringModelConcurrency:        getEnvAsInt("SCORING_MODEL_CONCURRENCY", 4),
+		DelinquencyConfirmationScrapes: getEnvAsInt("DELINQUENCY_CONFIRMATION_SCRAPES", 2),
+		// Requirements sweeper
+		RequirementsSweepIntervalMinutes: getEnvAsInt("REQUIREMENTS_SWEEP_INTERVAL_MINUTES", 15),
+		RequirementsSweepBatchSize:       getEnvAsInt("REQUIREMENTS_SWEEP_BATCH_SIZE", 100),
+		// History retention
+		HistoryRetentionDays:   getEnvAsInt("HISTORY_RETENTION_DAYS", 0),
+		RetentionIntervalHours: getEnvAsInt("RETENTION_INTERVAL_HOURS", 24),
+		// On-demand rescrape
+		RescrapeCooldownMinutes: getEnvAsInt("RESCRAPE_COOLDOWN_MINUTES", 15),
+		// History snapshot deduplication
+		SnapshotDedupeWindowHours: getEnvAsInt("SNAPSHOT_DEDUPE_WINDOW_HOURS", 24),
+		// Scrape job size limits
+		MaxJobTickersAdmin: getEnvAsInt("MAX_JOB_TICKERS_ADMIN", 10000),
+		MaxJobTickersUser:  getEnvAsInt("MAX_JOB_TICKERS_USER", 1000),
+		// OxyLabs URL fetching
+		OxyLabsBatchConcurrency:      getEnvAsInt("OXYLABS_BATCH_CONCURRENCY", 5),
+		OxyLabsRequestTimeoutSeconds: getEnvAsInt("OXYLABS_REQUEST_TIMEOUT_SECONDS", 180),
+		// Webhooks
+		WebhookURLs:           getEnv("WEBHOOK_URLS", ""),
+		WebhookSecret:         getEnv("WEBHOOK_SECRET", ""),
+		WebhookTimeoutSeconds: getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
 	}
 }
 
@@ -82,6 +167,14 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+// MaxJobTickers returns the largest scrape job the given role may submit
+func (c *Config) MaxJobTickers(role string) int {
+	if role == "admin" {
+		return c.MaxJobTickersAdmin
+	}
+	return c.MaxJobTickersUser
+}
+
 // GetAllowedOrigins returns a slice of allowed CORS origins
 func (c *Config) GetAllowedOrigins() []string {
 	if c.AllowedOrigins == "" {
@@ -102,6 +195,56 @@ func (c *Config) GetTrustedProxies() []string {
 	return strings.Split(c.TrustedProxies, ",")
 }
 
+// GetAllowedContentTypes returns the configured default request content types, if any
+func (c *Config) GetAllowedContentTypes() []string {
+	if c.AllowedContentTypes == "" {
+		return nil
+	}
+
+	var types []string
+	for _, t := range strings.Split(c.AllowedContentTypes, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// GetJWTPreviousKeys parses JWTPreviousKeys into key ID -> secret pairs
+func (c *Config) GetJWTPreviousKeys() map[string]string {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(c.JWTPreviousKeys, ",") {
+		kid, secret, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if found && kid != "" && secret != "" {
+			keys[kid] = secret
+		}
+	}
+	return keys
+}
+
+// GetMarketTierAliases parses MarketTierAliases into raw text -> tier pairs
+func (c *Config) GetMarketTierAliases() map[string]string {
+	aliases := make(map[string]string)
+	for _, pair := range strings.Split(c.MarketTierAliases, ",") {
+		raw, tier, found := strings.Cut(pair, "=")
+		if raw, tier = strings.TrimSpace(raw), strings.TrimSpace(tier); found && raw != "" && tier != "" {
+			aliases[raw] = tier
+		}
+	}
+	return aliases
+}
+
+// GetWebhookURLs returns the configured webhook URLs, if any
+func (c *Config) GetWebhookURLs() []string {
+	var urls []string
+	for _, u := range strings.Split(c.WebhookURLs, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
 // IsSecurityEnabled returns true if security features should be enabled
 func (c *Config) IsSecurityEnabled() bool {
 	return c.IsProduction() || getEnv("ENABLE_SECURITY", "false") == "true"